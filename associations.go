@@ -0,0 +1,230 @@
+package autofill
+
+import (
+	gocontext "context"
+	"fmt"
+	"reflect"
+)
+
+// Builder builds values for associations declared with BelongsTo and HasMany.
+// Every *Factory[T] is a Builder.
+type Builder interface {
+	// valueType returns the type of the values the builder builds.
+	valueType() reflect.Type
+
+	// buildValue builds a new value and returns a pointer to it.
+	buildValue(overrides []Override) (reflect.Value, error)
+
+	// createValue creates a new value and returns a pointer to it.
+	// fallback is used if the builder has no Persister.
+	createValue(ctx gocontext.Context, fallback Persister, overrides []Override) (reflect.Value, error)
+}
+
+// belongsTo is an association declared with BelongsTo.
+type belongsTo struct {
+	field  string
+	parent Builder
+	key    string
+}
+
+// hasMany is an association declared with HasMany.
+type hasMany struct {
+	field      string
+	child      Builder
+	min, max   int
+	foreignKey string
+	key        string
+}
+
+// BelongsTo declares that field of every value built by the factory refers to
+// a value built by parent. If key is empty, field is set to the parent itself,
+// or to a pointer to it; otherwise field is set to the key field of the parent,
+// such as its ID. The parent is not built if an override, default or trait
+// addresses field. It panics if the fields do not exist.
+//
+// Example:
+//
+//	posts := autofill.NewFactory[Post](nil).
+//	    BelongsTo("AuthorID", users, "ID")
+func (f *Factory[T]) BelongsTo(field string, parent Builder, key string) *Factory[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	target := mustField(typ, field)
+	if key == "" {
+		if !isValueOf(target.Type, parent.valueType()) {
+			panic(fmt.Sprintf("autofill: BelongsTo field %s.%s of type %s cannot hold a %s", typ, field, target.Type, parent.valueType()))
+		}
+	} else {
+		mustField(parent.valueType(), key)
+	}
+
+	f.belongsTo = append(f.belongsTo, belongsTo{field: field, parent: parent, key: key})
+	return f
+}
+
+// HasMany declares that field, a slice, of every value built by the factory
+// holds between min and max values built by child. The foreignKey field of
+// each child is set to the key field of the built value, such as its ID.
+// The number of children is derived from the seed and the index. The children
+// are not built if an override, default or trait addresses field.
+// It panics if the fields do not exist or min and max are invalid.
+//
+// Example:
+//
+//	orders := autofill.NewFactory[Order](nil).
+//	    HasMany("Items", orderItems, 1, 5, "OrderID", "ID")
+func (f *Factory[T]) HasMany(field string, child Builder, min, max int, foreignKey, key string) *Factory[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	target := mustField(typ, field)
+	if target.Type.Kind() != reflect.Slice || !isValueOf(target.Type.Elem(), child.valueType()) {
+		panic(fmt.Sprintf("autofill: HasMany field %s.%s of type %s cannot hold %s values", typ, field, target.Type, child.valueType()))
+	}
+	mustField(child.valueType(), foreignKey)
+	mustField(typ, key)
+	if min < 0 || max < min {
+		panic(fmt.Sprintf("autofill: HasMany requires 0 <= min <= max, got %d and %d", min, max))
+	}
+
+	f.hasMany = append(f.hasMany, hasMany{field: field, child: child, min: min, max: max, foreignKey: foreignKey, key: key})
+	return f
+}
+
+// valueType returns T.
+func (f *Factory[T]) valueType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// buildValue builds a new T and returns a pointer to it.
+func (f *Factory[T]) buildValue(overrides []Override) (reflect.Value, error) {
+	v, err := f.Build(overrides...)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(v), nil
+}
+
+// associateParents builds, or creates if s is not nil, the parents of v
+// declared with BelongsTo.
+func (f *Factory[T]) associateParents(v reflect.Value, overrides []Override, s *session) error {
+	if len(f.belongsTo) == 0 {
+		return nil
+	}
+
+	addressed, err := f.addressedFields(overrides)
+	if err != nil {
+		return err
+	}
+
+	for _, assoc := range f.belongsTo {
+		if addressed[assoc.field] {
+			continue
+		}
+		parent, err := s.associated(assoc.parent, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build %s: %w", assoc.field, err)
+		}
+		if err := setAssociated(v.FieldByName(assoc.field), parent, assoc.key); err != nil {
+			return fmt.Errorf("failed to set %s: %w", assoc.field, err)
+		}
+	}
+	return nil
+}
+
+// associateChildren builds, or creates if s is not nil, the children of v,
+// the value built with index, declared with HasMany.
+func (f *Factory[T]) associateChildren(v reflect.Value, index int, overrides []Override, s *session) error {
+	if len(f.hasMany) == 0 {
+		return nil
+	}
+
+	addressed, err := f.addressedFields(overrides)
+	if err != nil {
+		return err
+	}
+
+	for _, assoc := range f.hasMany {
+		if addressed[assoc.field] {
+			continue
+		}
+		field := v.FieldByName(assoc.field)
+		key := v.FieldByName(assoc.key).Interface()
+		n := assoc.min + f.pickCount(assoc.field, index, assoc.max-assoc.min+1)
+
+		children := reflect.MakeSlice(field.Type(), n, n)
+		for i := 0; i < n; i++ {
+			child, err := s.associated(assoc.child, []Override{{assoc.foreignKey: key}})
+			if err != nil {
+				return fmt.Errorf("failed to build %s[%d]: %w", assoc.field, i, err)
+			}
+			if err := setAssociated(children.Index(i), child, ""); err != nil {
+				return fmt.Errorf("failed to set %s[%d]: %w", assoc.field, i, err)
+			}
+		}
+		field.Set(children)
+	}
+	return nil
+}
+
+// associated builds a value with b, or creates it if s is not nil.
+func (s *session) associated(b Builder, overrides []Override) (reflect.Value, error) {
+	if s == nil {
+		return b.buildValue(overrides)
+	}
+	return b.createValue(s.ctx, s.persister, overrides)
+}
+
+// addressedFields returns the names of the fields addressed by the defaults,
+// traits and overrides of the factory.
+func (f *Factory[T]) addressedFields(overrides []Override) (map[string]bool, error) {
+	layers, err := f.filler.overrideLayers(overrides)
+	if err != nil {
+		return nil, err
+	}
+	addressed := make(map[string]bool)
+	for _, layer := range layers {
+		for key := range layer.override {
+			head, _ := splitPath(key)
+			addressed[head] = true
+		}
+	}
+	return addressed, nil
+}
+
+// pickCount returns a number in [0, n) for the association field of the value
+// built with index, derived like generated values from the seed and the index.
+func (f *Factory[T]) pickCount(field string, index, n int) int {
+	a := f.filler
+	ctx := newContext(a.locale, a.seed, index, nil)
+	ctx.randomized = a.mode == RandomMode
+	ctx.source = a.source
+	return pick(ctx.withType(f.valueType()).withFieldName(field), n)
+}
+
+// setAssociated sets dst to the key field of the value pointed to by ptr,
+// or, if key is empty, to the value or the pointer itself.
+func setAssociated(dst, ptr reflect.Value, key string) error {
+	if key != "" {
+		return setFieldValue(dst, ptr.Elem().FieldByName(key).Interface())
+	}
+	if dst.Kind() == reflect.Ptr {
+		dst.Set(ptr)
+	} else {
+		dst.Set(ptr.Elem())
+	}
+	return nil
+}
+
+// mustField returns the exported field name of the struct typ, panicking if
+// there is none.
+func mustField(typ reflect.Type, name string) reflect.StructField {
+	if typ.Kind() == reflect.Struct {
+		if field, ok := typ.FieldByName(name); ok && field.IsExported() {
+			return field
+		}
+	}
+	panic(fmt.Sprintf("autofill: %s has no exported field %q", typ, name))
+}
+
+// isValueOf reports whether typ is elem or a pointer to elem.
+func isValueOf(typ, elem reflect.Type) bool {
+	return typ == elem || (typ.Kind() == reflect.Ptr && typ.Elem() == elem)
+}
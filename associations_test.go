@@ -0,0 +1,135 @@
+package autofill
+
+import (
+	"strings"
+	"testing"
+)
+
+type assocUser struct {
+	ID   int64 `autofill:"seq"`
+	Name string
+}
+
+type assocPost struct {
+	ID       int64 `autofill:"seq"`
+	AuthorID int64
+	Author   *assocUser
+	Title    string
+}
+
+type assocOrder struct {
+	ID    int64 `autofill:"seq"`
+	Items []assocOrderItem
+}
+
+type assocOrderItem struct {
+	ID      int64 `autofill:"seq"`
+	OrderID int64
+	SKU     string
+}
+
+func TestFactory_BelongsTo(t *testing.T) {
+	users := NewFactory[assocUser](nil).WithDefaults(Override{"ID": SeqInt(100)})
+	posts := NewFactory[assocPost](nil).
+		BelongsTo("AuthorID", users, "ID").
+		BelongsTo("Author", users, "")
+
+	p1 := posts.MustBuild()
+	p2 := posts.MustBuild()
+	if p1.AuthorID != 100 || p2.AuthorID != 102 {
+		t.Errorf("expected author IDs 100 and 102, got %d and %d", p1.AuthorID, p2.AuthorID)
+	}
+	if p1.Author == nil || p1.Author.ID != 101 {
+		t.Errorf("expected Author with ID 101, got %+v", p1.Author)
+	}
+
+	p3 := posts.MustBuild(Override{"AuthorID": int64(7)})
+	if p3.AuthorID != 7 {
+		t.Errorf("expected overridden AuthorID 7, got %d", p3.AuthorID)
+	}
+}
+
+func TestFactory_HasMany(t *testing.T) {
+	items := NewFactory[assocOrderItem](nil)
+	orders := NewFactory[assocOrder](nil).
+		WithDefaults(Override{"ID": SeqInt(1)}).
+		HasMany("Items", items, 1, 5, "OrderID", "ID")
+
+	list, err := orders.BuildN(10)
+	if err != nil {
+		t.Fatalf("BuildN failed: %v", err)
+	}
+
+	seen := make(map[int64]bool)
+	for _, o := range list {
+		if len(o.Items) < 1 || len(o.Items) > 5 {
+			t.Errorf("order %d: expected 1 to 5 items, got %d", o.ID, len(o.Items))
+		}
+		for _, item := range o.Items {
+			if item.OrderID != o.ID {
+				t.Errorf("order %d: item has OrderID %d", o.ID, item.OrderID)
+			}
+			if seen[item.ID] {
+				t.Errorf("item ID %d was built twice", item.ID)
+			}
+			seen[item.ID] = true
+		}
+	}
+
+	o := orders.MustBuild(Override{"Items": []assocOrderItem{}})
+	if len(o.Items) != 0 {
+		t.Errorf("expected overridden Items to be empty, got %d", len(o.Items))
+	}
+}
+
+func TestFactory_HasManyDeterministic(t *testing.T) {
+	build := func() []int {
+		items := NewFactory[assocOrderItem](nil)
+		orders := NewFactory[assocOrder](New().WithSeed(3).WithMode(RandomMode)).
+			HasMany("Items", items, 0, 5, "OrderID", "ID")
+		list, err := orders.BuildN(5)
+		if err != nil {
+			t.Fatalf("BuildN failed: %v", err)
+		}
+		counts := make([]int, len(list))
+		for i, o := range list {
+			counts[i] = len(o.Items)
+		}
+		return counts
+	}
+
+	first, second := build(), build()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected the same item counts, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestFactory_AssociationPanics(t *testing.T) {
+	users := NewFactory[assocUser](nil)
+	items := NewFactory[assocOrderItem](nil)
+
+	tests := []struct {
+		name string
+		fn   func()
+		want string
+	}{
+		{"unknown field", func() { NewFactory[assocPost](nil).BelongsTo("Missing", users, "ID") }, `no exported field "Missing"`},
+		{"unknown key", func() { NewFactory[assocPost](nil).BelongsTo("AuthorID", users, "Missing") }, `no exported field "Missing"`},
+		{"wrong type", func() { NewFactory[assocPost](nil).BelongsTo("Title", users, "") }, "cannot hold"},
+		{"not a slice", func() { NewFactory[assocOrder](nil).HasMany("ID", items, 1, 2, "OrderID", "ID") }, "cannot hold"},
+		{"invalid range", func() { NewFactory[assocOrder](nil).HasMany("Items", items, 3, 2, "OrderID", "ID") }, "min <= max"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil || !strings.Contains(r.(string), tt.want) {
+					t.Errorf("expected panic containing %q, got %v", tt.want, r)
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
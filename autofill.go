@@ -22,9 +22,12 @@
 package autofill
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/m1a9s9a4/autofill/rules"
@@ -32,23 +35,51 @@ import (
 
 // Autofill is the main struct for generating test data.
 // Create an instance using New() and configure it with With* methods.
+//
+// Once configured, an Autofill is safe for concurrent use by multiple goroutines:
+// Fill and FillSlice only read the configuration, and every field draws from its
+// own random stream. Override values such as SequenceFunc must then be safe for
+// concurrent use as well; the built-in sequences are.
 type Autofill struct {
-	locale   string
-	seed     int64
-	rules    *rules.RuleSet
-	rand     *rand.Rand
-	defaults Override
+	locale    string
+	seed      int64
+	rules     *rules.RuleSet
+	mode      Mode
+	clock     func() time.Time
+	workers   int
+	edges     float64
+	source    SourceFunc
+	enums     map[reflect.Type][]interface{}
+	defaults  Override
+	traits    map[string]Override
+	strict    bool
+	persister Persister
+	created   *sync.Map // reflect.Type to *atomic.Int64, the next index of Create
 }
 
+// Mode controls how the built-in generators and rules derive their values.
+type Mode int
+
+const (
+	// SequentialMode derives values from the fill index, so the same index
+	// always produces the same value regardless of the seed. This is the default.
+	SequentialMode Mode = iota
+
+	// RandomMode draws values from the seeded random number generator.
+	// Using the same seed reproduces the same data.
+	RandomMode
+)
+
 // New creates a new Autofill instance with default settings.
 // Default locale is "en_US" and seed is based on current time.
 func New() *Autofill {
 	seed := time.Now().UnixNano()
 	return &Autofill{
-		locale: "en_US",
-		seed:   seed,
-		rules:  rules.DefaultRuleSet(),
-		rand:   rand.New(rand.NewSource(seed)),
+		locale:  "en_US",
+		seed:    seed,
+		rules:   rules.DefaultRuleSet(),
+		clock:   time.Now,
+		created: &sync.Map{},
 	}
 }
 
@@ -63,7 +94,67 @@ func (a *Autofill) WithLocale(locale string) *Autofill {
 // Using the same seed will produce the same results.
 func (a *Autofill) WithSeed(seed int64) *Autofill {
 	a.seed = seed
-	a.rand = rand.New(rand.NewSource(seed))
+	return a
+}
+
+// WithMode sets how values are generated.
+// In RandomMode every built-in generator draws from the seeded random number
+// generator, so combine it with WithSeed for reproducible data:
+//
+//	af := autofill.New().WithSeed(42).WithMode(autofill.RandomMode)
+func (a *Autofill) WithMode(mode Mode) *Autofill {
+	a.mode = mode
+	return a
+}
+
+// WithClock sets the clock used as the reference time by time-related generators,
+// such as time.Time fields and the "now" tag. The clock is read once per Fill.
+// Use a fixed time together with WithSeed for fully reproducible data:
+//
+//	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	af := autofill.New().WithSeed(42).WithClock(func() time.Time { return ref })
+//
+// A nil clock restores the default, time.Now.
+func (a *Autofill) WithClock(clock func() time.Time) *Autofill {
+	if clock == nil {
+		clock = time.Now
+	}
+	a.clock = clock
+	return a
+}
+
+// WithWorkers sets the number of goroutines FillSlice uses to fill elements.
+// Values depend only on the seed and the element index, so the output is
+// identical to a sequential fill. A value of 1 or less fills sequentially,
+// which is the default.
+//
+//	af := autofill.New().WithSeed(42).WithWorkers(runtime.GOMAXPROCS(0))
+//	af.FillSlice(&users)
+func (a *Autofill) WithWorkers(n int) *Autofill {
+	a.workers = n
+	return a
+}
+
+// WithEdgeCases makes generators emit boundary values for a ratio (0.0 to 1.0)
+// of the generated values: empty, very long, multibyte and emoji strings,
+// minimum and maximum integers for the exact field width, NaN and infinite
+// floats, zero and far-future times, and nil pointers, slices and maps.
+// Values constrained by tags, such as min/max and oneof, stay within their
+// declared bounds. The default ratio is 0, which disables edge cases.
+//
+//	af := autofill.New().WithSeed(42).WithEdgeCases(0.2)
+func (a *Autofill) WithEdgeCases(ratio float64) *Autofill {
+	a.edges = math.Max(0, math.Min(1, ratio))
+	return a
+}
+
+// WithSource sets the random source used by every generator and rule.
+// Use ChaCha8 or MathRand instead of the default PCG, or provide your own
+// SourceFunc.
+//
+//	af := autofill.New().WithSeed(42).WithSource(autofill.ChaCha8())
+func (a *Autofill) WithSource(source SourceFunc) *Autofill {
+	a.source = source
 	return a
 }
 
@@ -109,6 +200,13 @@ func (a *Autofill) Fill(v interface{}, overrides ...Override) error {
 // This is useful for deterministic generation when you want different values
 // but don't want to fill a slice.
 func (a *Autofill) FillWithIndex(v interface{}, index int, overrides ...Override) error {
+	return a.fill(v, index, nil, nil, overrides)
+}
+
+// fill fills the struct pointed to by v. If r is not nil, every generator
+// draws from it in random mode instead of from the per-field streams.
+// If trace is not nil, it records how each value was produced.
+func (a *Autofill) fill(v interface{}, index int, r *rand.Rand, trace *Trace, overrides []Override) error {
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Ptr {
 		return fmt.Errorf("Fill requires a pointer to struct, got %T", v)
@@ -119,46 +217,109 @@ func (a *Autofill) FillWithIndex(v interface{}, index int, overrides ...Override
 		return fmt.Errorf("Fill requires a pointer to struct, got pointer to %s", elem.Kind())
 	}
 
-	// Merge overrides (defaults < passed overrides)
-	override := a.mergeWithDefaults(overrides)
+	// Merge overrides (defaults < traits < passed overrides)
+	layers, err := a.overrideLayers(overrides)
+	if err != nil {
+		return err
+	}
+	override := mergeLayers(layers)
+
+	if a.strict {
+		if err := a.validateOverrides(elem.Type(), overrides); err != nil {
+			return err
+		}
+	}
 
 	// Create context
-	ctx := newContext(a.locale, a.seed, index, a.rand)
-	ctx = ctx.withStruct(v)
+	ctx := newContext(a.locale, a.seed, index, r)
+	ctx.randomized = a.mode == RandomMode || r != nil
+	ctx.now = a.clock()
+	ctx.strict = a.strict
+	ctx.edgeRatio = a.edges
+	ctx.source = a.source
+	if trace != nil {
+		ctx.trace = trace
+		trace.layers = layers
+	}
+	override, ctx.types = splitTypeOverrides(override)
+	ctx = ctx.withType(elem.Type()).withOverrides(override)
 
-	// Fill each field
-	typ := elem.Type()
-	for i := 0; i < elem.NumField(); i++ {
-		field := typ.Field(i)
-		fieldVal := elem.Field(i)
+	err = a.fillStruct(elem, ctx)
+	var rangeErr *indexRangeError
+	if errors.As(err, &rangeErr) {
+		return &OverrideError{Type: elem.Type(), Keys: []InvalidKey{indexRangeKey(layers, rangeErr)}}
+	}
+	return err
+}
 
-		if !fieldVal.CanSet() {
+// fillStruct fills each settable field of the struct v, applying the overrides
+// in ctx. Keys may address nested fields, e.g. "Address.City" or "Items[*].Price".
+// Fields overridden by an OverrideFunc are filled last, so that the function
+// can read the values of all other fields.
+func (a *Autofill) fillStruct(v reflect.Value, ctx *context) error {
+	structCtx := ctx.withStruct(v.Addr().Interface())
+	scoped := scopeOverrides(ctx.overrides)
+
+	var deferred []int
+	typ := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		if _, ok := scoped[typ.Field(i).Name].value.(OverrideFunc); ok {
+			deferred = append(deferred, i)
 			continue
 		}
+		if err := a.fillField(v, i, structCtx, scoped); err != nil {
+			return err
+		}
+	}
 
-		fieldCtx := ctx.withFieldName(field.Name)
-
-		// Check for override
-		if overrideVal, ok := override[field.Name]; ok {
-			resolved := resolveOverride(overrideVal, index)
-			if resolved != nil {
-				if err := setFieldValue(fieldVal, resolved); err != nil {
-					return fmt.Errorf("failed to set override for field %s: %w", field.Name, err)
-				}
-				continue
-			}
+	for _, i := range deferred {
+		if err := a.fillField(v, i, structCtx, scoped); err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
+
+// fillField fills the i-th field of the struct v with its override or a generated value.
+func (a *Autofill) fillField(v reflect.Value, i int, structCtx *context, scoped scopedOverrides) error {
+	field := v.Type().Field(i)
+	fieldVal := v.Field(i)
 
-		// Generate value
-		val, err := a.generateValue(field, fieldCtx)
+	if !fieldVal.CanSet() {
+		return nil
+	}
+
+	fieldCtx := structCtx.withFieldName(field.Name)
+	scope := scoped[field.Name]
+
+	// Check for override
+	nested := scope.nested
+	if scope.hasValue {
+		resolved, err := resolveOverride(scope.value, fieldCtx)
 		if err != nil {
-			return fmt.Errorf("failed to generate value for field %s: %w", field.Name, err)
+			return fmt.Errorf("failed to resolve override for field %s: %w", fieldCtx.Path(), err)
 		}
-
-		if val != nil {
-			if err := setFieldValue(fieldVal, val); err != nil {
-				return fmt.Errorf("failed to set value for field %s: %w", field.Name, err)
+		if sub, ok := resolved.(Override); ok {
+			nested = mergeOverrides([]Override{sub, nested})
+		} else if resolved != nil {
+			if err := setFieldValue(fieldVal, resolved); err != nil {
+				return fmt.Errorf("failed to set override for field %s: %w", fieldCtx.Path(), err)
 			}
+			fieldCtx.recordOverride(scope.value)
+			return a.applyOverrides(fieldVal, fieldCtx.withOverrides(nested))
+		}
+	}
+
+	// Generate value
+	val, err := a.generateValue(field, fieldCtx.withOverrides(nested))
+	if err != nil {
+		return fmt.Errorf("failed to generate value for field %s: %w", fieldCtx.Path(), err)
+	}
+
+	if val != nil {
+		if err := setFieldValue(fieldVal, val); err != nil {
+			return fmt.Errorf("failed to set value for field %s: %w", fieldCtx.Path(), err)
 		}
 	}
 
@@ -188,6 +349,10 @@ func (a *Autofill) FillSlice(v interface{}, overrides ...Override) error {
 	}
 
 	sliceLen := elem.Len()
+	if a.workers > 1 && sliceLen > 1 {
+		return a.fillSliceParallel(elem, overrides)
+	}
+
 	for i := 0; i < sliceLen; i++ {
 		item := elem.Index(i).Addr().Interface()
 		if err := a.FillWithIndex(item, i, overrides...); err != nil {
@@ -198,6 +363,48 @@ func (a *Autofill) FillSlice(v interface{}, overrides ...Override) error {
 	return nil
 }
 
+// fillSliceParallel fills the elements of slice across a pool of workers,
+// each taking a contiguous range of indexes. If several elements fail,
+// the error of the lowest index is returned, as in a sequential fill.
+func (a *Autofill) fillSliceParallel(slice reflect.Value, overrides []Override) error {
+	sliceLen := slice.Len()
+	workers := a.workers
+	if workers > sliceLen {
+		workers = sliceLen
+	}
+	chunk := (sliceLen + workers - 1) / workers
+
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w * chunk
+		end := start + chunk
+		if end > sliceLen {
+			end = sliceLen
+		}
+
+		wg.Add(1)
+		go func(w, start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				item := slice.Index(i).Addr().Interface()
+				if err := a.FillWithIndex(item, i, overrides...); err != nil {
+					errs[w] = fmt.Errorf("failed to fill slice element at index %d: %w", i, err)
+					return
+				}
+			}
+		}(w, start, end)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Fill is a convenience function that creates a new Autofill instance
 // and fills the given struct.
 func Fill(v interface{}, overrides ...Override) error {
@@ -230,20 +437,46 @@ func mergeOverrides(overrides []Override) Override {
 }
 
 // mergeWithDefaults merges the defaults with the provided overrides.
-// Priority: defaults < passed overrides (passed overrides take precedence)
-func (a *Autofill) mergeWithDefaults(overrides []Override) Override {
+// Priority: defaults < traits < passed overrides (passed overrides take precedence)
+func (a *Autofill) mergeWithDefaults(overrides []Override) (Override, error) {
+	layers, err := a.overrideLayers(overrides)
+	if err != nil {
+		return nil, err
+	}
+	return mergeLayers(layers), nil
+}
+
+// overrideLayers returns the defaults and the provided overrides, with their
+// traits expanded, in increasing precedence.
+func (a *Autofill) overrideLayers(overrides []Override) ([]overrideLayer, error) {
 	if a.defaults == nil && len(overrides) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	// Prepend defaults to overrides so they have lower priority
-	allOverrides := make([]Override, 0, len(overrides)+1)
+	// Expand traits of the defaults before those of the passed overrides,
+	// so that they have lower priority
+	var layers []overrideLayer
 	if a.defaults != nil {
-		allOverrides = append(allOverrides, a.defaults)
+		defaults, err := a.expandTraits([]Override{a.defaults}, OriginDefault)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, defaults...)
 	}
-	allOverrides = append(allOverrides, overrides...)
+	passed, err := a.expandTraits(overrides, OriginOverride)
+	if err != nil {
+		return nil, err
+	}
+	return append(layers, passed...), nil
+}
 
-	return mergeOverrides(allOverrides)
+// mergeLayers merges the overrides of layers into one.
+func mergeLayers(layers []overrideLayer) Override {
+	overrides := make([]Override, len(layers))
+	for i, layer := range layers {
+		overrides[i] = layer.override
+	}
+	return mergeOverrides(overrides)
 }
 
 // setFieldValue sets a reflect.Value with the given value, handling type conversions.
@@ -252,6 +485,11 @@ func setFieldValue(field reflect.Value, value interface{}) error {
 		return nil
 	}
 
+	// Values loaded from fixture files are converted to the field type
+	if fixture, ok := value.(fixtureValue); ok {
+		return fixture.set(field)
+	}
+
 	valReflect := reflect.ValueOf(value)
 	fieldType := field.Type()
 
@@ -45,6 +45,73 @@ func TestWithSeed(t *testing.T) {
 	}
 }
 
+func TestWithClock(t *testing.T) {
+	type Event struct {
+		At        time.Time `autofill:"now"`
+		CreatedAt time.Time
+		UpdatedAt *time.Time
+	}
+
+	ref := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	af := New().WithSeed(1).WithClock(func() time.Time { return ref })
+
+	events := make([]Event, 5)
+	if err := af.FillSlice(&events); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	for i, e := range events {
+		if !e.At.Equal(ref) {
+			t.Errorf("event %d: expected At %v, got %v", i, ref, e.At)
+		}
+		if e.CreatedAt.After(ref) || e.CreatedAt.Before(ref.AddDate(-1, 0, 0)) {
+			t.Errorf("event %d: CreatedAt %v is not within a year before %v", i, e.CreatedAt, ref)
+		}
+		if e.UpdatedAt == nil || e.UpdatedAt.After(ref) {
+			t.Errorf("event %d: unexpected UpdatedAt %v", i, e.UpdatedAt)
+		}
+	}
+
+	again := make([]Event, 5)
+	if err := New().WithSeed(1).WithClock(func() time.Time { return ref }).FillSlice(&again); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+	for i := range events {
+		if !events[i].CreatedAt.Equal(again[i].CreatedAt) {
+			t.Errorf("event %d: expected reproducible CreatedAt, got %v and %v", i, events[i].CreatedAt, again[i].CreatedAt)
+		}
+	}
+}
+
+func TestWithClock_ZeroTime(t *testing.T) {
+	type Event struct {
+		At time.Time `autofill:"now"`
+	}
+
+	var e Event
+	if err := New().WithClock(func() time.Time { return time.Time{} }).Fill(&e); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if !e.At.IsZero() {
+		t.Errorf("expected the zero time from the clock, got %v", e.At)
+	}
+}
+
+func TestWithClock_Nil(t *testing.T) {
+	type Event struct {
+		At time.Time `autofill:"now"`
+	}
+
+	var e Event
+	before := time.Now()
+	if err := New().WithClock(nil).Fill(&e); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if e.At.Before(before) || e.At.After(time.Now()) {
+		t.Errorf("expected the current time, got %v", e.At)
+	}
+}
+
 func TestFill_BasicTypes(t *testing.T) {
 	var user TestUser
 	err := Fill(&user)
@@ -328,3 +395,13 @@ func BenchmarkFillSlice(b *testing.B) {
 		af.FillSlice(&users)
 	}
 }
+
+func BenchmarkFillSlice_RandomMode(b *testing.B) {
+	af := New().WithSeed(1).WithMode(RandomMode)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		users := make([]TestUser, 100)
+		af.FillSlice(&users)
+	}
+}
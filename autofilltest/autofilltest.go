@@ -0,0 +1,121 @@
+// Package autofilltest provides helpers for using autofill in tests.
+//
+// It picks a random seed for each test and pins the clock to the time the
+// test started, logs both when the test fails, and replays them so failures
+// seen in CI can be reproduced locally:
+//
+//	func TestUser(t *testing.T) {
+//	    af := autofilltest.New(t)
+//
+//	    var user User
+//	    if err := af.Fill(&user); err != nil {
+//	        t.Fatal(err)
+//	    }
+//	    // ...
+//	}
+//
+// When the test fails, the seed and the time are logged. Replay them with either:
+//
+//	go test -run TestUser -autofill.seed=1234567890 -autofill.now=2024-01-02T03:04:05.123456789Z
+//	AUTOFILL_SEED=1234567890 AUTOFILL_NOW=2024-01-02T03:04:05.123456789Z go test ./...
+//
+// The flags are only defined for test binaries that import this package,
+// so prefer the environment variables when running several packages at once.
+package autofilltest
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m1a9s9a4/autofill"
+)
+
+// SeedEnv is the environment variable used to replay a seed.
+const SeedEnv = "AUTOFILL_SEED"
+
+// NowEnv is the environment variable used to replay the time, in RFC 3339 format.
+const NowEnv = "AUTOFILL_NOW"
+
+var (
+	seedFlag = flag.String("autofill.seed", "", "replay autofill data generated with this seed")
+	nowFlag  = flag.String("autofill.now", "", "replay autofill data generated at this RFC 3339 time")
+)
+
+// New returns an Autofill in random mode, seeded by Seed, whose clock is
+// fixed at Now, so that "now" tags and time rules replay as well.
+func New(t testing.TB) *autofill.Autofill {
+	t.Helper()
+	now := Now(t)
+	return autofill.New().
+		WithSeed(Seed(t)).
+		WithMode(autofill.RandomMode).
+		WithClock(func() time.Time { return now })
+}
+
+// Seed returns the seed to use for t.
+// It is taken from the -autofill.seed flag or the AUTOFILL_SEED environment
+// variable if set, and picked from the current time otherwise.
+// The seed is logged if t fails.
+func Seed(t testing.TB) int64 {
+	t.Helper()
+
+	var seed int64
+	value, source := replayValue(*seedFlag, "-autofill.seed", SeedEnv)
+	if value != "" {
+		var err error
+		if seed, err = strconv.ParseInt(value, 10, 64); err != nil {
+			t.Fatalf("autofilltest: invalid %s %q: %v", source, value, err)
+		}
+	} else {
+		seed = time.Now().UnixNano()
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("autofill seed: %d (replay with -autofill.seed=%d or %s=%d)", seed, seed, SeedEnv, seed)
+		}
+	})
+
+	return seed
+}
+
+// Now returns the time to use as the clock for t.
+// It is taken from the -autofill.now flag or the AUTOFILL_NOW environment
+// variable if set, and is the current time in UTC otherwise.
+// The time is logged if t fails.
+func Now(t testing.TB) time.Time {
+	t.Helper()
+
+	var now time.Time
+	value, source := replayValue(*nowFlag, "-autofill.now", NowEnv)
+	if value != "" {
+		var err error
+		if now, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			t.Fatalf("autofilltest: invalid %s %q: %v", source, value, err)
+		}
+	} else {
+		// UTC and without a monotonic reading, so the logged time replays exactly
+		now = time.Now().UTC().Round(0)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			formatted := now.Format(time.RFC3339Nano)
+			t.Logf("autofill now: %s (replay with -autofill.now=%s or %s=%s)", formatted, formatted, NowEnv, formatted)
+		}
+	})
+
+	return now
+}
+
+// replayValue returns the value of the flag, or else of the environment
+// variable env, and the name of its source. The flag takes precedence.
+func replayValue(flagValue, flagName, env string) (value, source string) {
+	if flagValue != "" {
+		return flagValue, flagName
+	}
+	return os.Getenv(env), env
+}
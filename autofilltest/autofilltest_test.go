@@ -0,0 +1,167 @@
+package autofilltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recorder wraps a testing.TB to capture logs, fatal errors and cleanups.
+type recorder struct {
+	testing.TB
+	failed   bool
+	logs     []string
+	fatals   []string
+	cleanups []func()
+}
+
+func (r *recorder) Helper()          {}
+func (r *recorder) Failed() bool     { return r.failed }
+func (r *recorder) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+func (r *recorder) Logf(format string, args ...interface{}) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+type user struct {
+	Name  string
+	Email string `autofill:"email"`
+	Age   int    `autofill:"min=18,max=65"`
+}
+
+func TestSeed_FromEnv(t *testing.T) {
+	t.Setenv(SeedEnv, "12345")
+
+	rec := &recorder{TB: t}
+	if seed := Seed(rec); seed != 12345 {
+		t.Errorf("expected seed 12345, got %d", seed)
+	}
+}
+
+func TestSeed_FromFlag(t *testing.T) {
+	t.Setenv(SeedEnv, "1")
+	old := *seedFlag
+	*seedFlag = "777"
+	defer func() { *seedFlag = old }()
+
+	rec := &recorder{TB: t}
+	if seed := Seed(rec); seed != 777 {
+		t.Errorf("expected flag to take precedence with seed 777, got %d", seed)
+	}
+}
+
+func TestSeed_InvalidEnv(t *testing.T) {
+	t.Setenv(SeedEnv, "not-a-number")
+
+	rec := &recorder{TB: t}
+	Seed(rec)
+	if len(rec.fatals) != 1 || !strings.Contains(rec.fatals[0], SeedEnv) {
+		t.Errorf("expected fatal error mentioning %s, got %v", SeedEnv, rec.fatals)
+	}
+}
+
+func TestSeed_LogsOnlyOnFailure(t *testing.T) {
+	t.Setenv(SeedEnv, "")
+
+	passed := &recorder{TB: t}
+	Seed(passed)
+	passed.finish()
+	if len(passed.logs) != 0 {
+		t.Errorf("expected no logs for a passing test, got %v", passed.logs)
+	}
+
+	failed := &recorder{TB: t}
+	seed := Seed(failed)
+	failed.failed = true
+	failed.finish()
+	if len(failed.logs) != 1 || !strings.Contains(failed.logs[0], fmt.Sprint(seed)) {
+		t.Errorf("expected seed %d to be logged, got %v", seed, failed.logs)
+	}
+}
+
+func TestNew_ReplaysData(t *testing.T) {
+	t.Setenv(SeedEnv, "42")
+
+	users1 := make([]user, 5)
+	if err := New(&recorder{TB: t}).FillSlice(&users1); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	users2 := make([]user, 5)
+	if err := New(&recorder{TB: t}).FillSlice(&users2); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(users1, users2) {
+		t.Errorf("expected replayed seed to produce the same data, got %+v and %+v", users1, users2)
+	}
+}
+
+func TestNow_FromEnv(t *testing.T) {
+	t.Setenv(NowEnv, "2024-01-02T03:04:05.123456789Z")
+
+	rec := &recorder{TB: t}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	if now := Now(rec); !now.Equal(want) {
+		t.Errorf("expected %v, got %v", want, now)
+	}
+
+	t.Setenv(NowEnv, "yesterday")
+	Now(rec)
+	if len(rec.fatals) != 1 || !strings.Contains(rec.fatals[0], NowEnv) {
+		t.Errorf("expected fatal error mentioning %s, got %v", NowEnv, rec.fatals)
+	}
+}
+
+func TestNow_LogsOnlyOnFailure(t *testing.T) {
+	t.Setenv(NowEnv, "")
+
+	passed := &recorder{TB: t}
+	Now(passed)
+	passed.finish()
+	if len(passed.logs) != 0 {
+		t.Errorf("expected no logs for a passing test, got %v", passed.logs)
+	}
+
+	failed := &recorder{TB: t}
+	now := Now(failed)
+	failed.failed = true
+	failed.finish()
+	formatted := now.Format(time.RFC3339Nano)
+	if len(failed.logs) != 1 || !strings.Contains(failed.logs[0], NowEnv+"="+formatted) {
+		t.Fatalf("expected time %s to be logged, got %v", formatted, failed.logs)
+	}
+
+	t.Setenv(NowEnv, formatted)
+	if replayed := Now(&recorder{TB: t}); replayed != now {
+		t.Errorf("expected the logged time to replay exactly, got %v and %v", now, replayed)
+	}
+}
+
+func TestNew_PinsClock(t *testing.T) {
+	type event struct {
+		At time.Time `autofill:"now"`
+	}
+	t.Setenv(SeedEnv, "42")
+	t.Setenv(NowEnv, "2024-01-02T03:04:05Z")
+
+	var e event
+	if err := New(&recorder{TB: t}).Fill(&e); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC); !e.At.Equal(want) {
+		t.Errorf("expected the clock to be pinned at %v, got %v", want, e.At)
+	}
+}
@@ -0,0 +1,299 @@
+package autofill
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TestingT is the subset of testing.TB used by Check.
+// *testing.T, *testing.B and *testing.F implement it.
+type TestingT interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// CheckOption configures Check.
+type CheckOption func(*checkConfig)
+
+type checkConfig struct {
+	runs       int
+	maxShrinks int
+	filler     *Autofill
+	overrides  []Override
+}
+
+// CheckRuns sets the number of generated values the property is checked against.
+// The default is 100.
+func CheckRuns(n int) CheckOption {
+	return func(c *checkConfig) {
+		c.runs = n
+	}
+}
+
+// CheckMaxShrinks limits the number of property evaluations spent shrinking
+// a counterexample. The default is 1000.
+func CheckMaxShrinks(n int) CheckOption {
+	return func(c *checkConfig) {
+		c.maxShrinks = n
+	}
+}
+
+// CheckWith sets the Autofill used to generate values, including its seed,
+// rules and defaults. By default a new Autofill in RandomMode is used.
+func CheckWith(a *Autofill) CheckOption {
+	return func(c *checkConfig) {
+		c.filler = a
+	}
+}
+
+// CheckOverrides sets overrides applied to every generated value.
+func CheckOverrides(overrides ...Override) CheckOption {
+	return func(c *checkConfig) {
+		c.overrides = append(c.overrides, overrides...)
+	}
+}
+
+// Check verifies that prop holds for generated values of the struct type T.
+// Values are generated with FillWithIndex, so tags, rules and overrides are respected.
+//
+// If prop returns false or panics, the counterexample is shrunk towards a
+// minimal failing value: shorter strings and slices, smaller numbers and nil
+// pointers. Fields with min/max or oneof tags stay within their declared
+// constraints, and fields generated by other tags or rules are not shrunk.
+// The minimal value and the seed are then reported with t.Fatalf.
+//
+// Example:
+//
+//	autofill.Check(t, func(u User) bool {
+//	    return validate(u) == nil
+//	}, autofill.CheckRuns(500))
+func Check[T any](t TestingT, prop func(T) bool, opts ...CheckOption) {
+	t.Helper()
+
+	cfg := checkConfig{runs: 100, maxShrinks: 1000}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.filler == nil {
+		cfg.filler = New().WithMode(RandomMode)
+	}
+
+	fails := func(v T) (failed bool) {
+		defer func() {
+			if r := recover(); r != nil {
+				failed = true
+			}
+		}()
+		return !prop(v)
+	}
+
+	for i := 0; i < cfg.runs; i++ {
+		var v T
+		if err := cfg.filler.FillWithIndex(&v, i, cfg.overrides...); err != nil {
+			t.Fatalf("autofill.Check: failed to generate value %d: %v", i, err)
+			return
+		}
+		if !fails(v) {
+			continue
+		}
+
+		minimal := shrinkCounterexample(v, fails, cfg.maxShrinks)
+		t.Fatalf("autofill.Check: property failed after %d runs (seed %d, index %d)\nminimal counterexample: %+v\noriginal counterexample: %+v",
+			i+1, cfg.filler.seed, i, minimal, v)
+		return
+	}
+}
+
+// shrinkCounterexample repeatedly replaces v with the first simpler candidate
+// that still fails, until no candidate fails or the budget is spent.
+func shrinkCounterexample[T any](v T, fails func(T) bool, budget int) T {
+	cur := reflect.ValueOf(&v).Elem()
+	for budget > 0 {
+		improved := false
+		for _, cand := range shrinkCandidates(cur, "") {
+			if budget == 0 {
+				break
+			}
+			budget--
+			if fails(cand.Interface().(T)) {
+				cur = cand
+				improved = true
+				break
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return cur.Interface().(T)
+}
+
+// shrinkCandidates returns simpler values of the same type as v, simplest first.
+// tag is the autofill tag of the field holding v, if any.
+func shrinkCandidates(v reflect.Value, tag string) []reflect.Value {
+	if tag != "" && tag != "-" {
+		return shrinkTagged(v, tag)
+	}
+
+	var cands []reflect.Value
+	add := func(c reflect.Value) {
+		if !reflect.DeepEqual(c.Interface(), v.Interface()) {
+			cands = append(cands, c)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		// Cut by rune, so that multi-byte characters stay valid UTF-8
+		if r := []rune(v.String()); len(r) > 0 {
+			add(reflect.ValueOf("").Convert(v.Type()))
+			add(reflect.ValueOf(string(r[:len(r)/2])).Convert(v.Type()))
+			add(reflect.ValueOf(string(r[:len(r)-1])).Convert(v.Type()))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		for _, n := range shrinkInt(v.Int(), 0) {
+			add(reflect.ValueOf(n).Convert(v.Type()))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n := v.Uint(); n != 0 {
+			add(reflect.ValueOf(uint64(0)).Convert(v.Type()))
+			add(reflect.ValueOf(n / 2).Convert(v.Type()))
+			add(reflect.ValueOf(n - 1).Convert(v.Type()))
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f != 0 {
+			add(reflect.ValueOf(0.0).Convert(v.Type()))
+			add(reflect.ValueOf(float64(int64(f))).Convert(v.Type()))
+			add(reflect.ValueOf(f / 2).Convert(v.Type()))
+		}
+	case reflect.Bool:
+		if v.Bool() {
+			add(reflect.ValueOf(false).Convert(v.Type()))
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			add(reflect.Zero(v.Type()))
+			for _, c := range shrinkCandidates(v.Elem(), "") {
+				ptr := reflect.New(v.Type().Elem())
+				ptr.Elem().Set(c)
+				add(ptr)
+			}
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			cands = append(cands, shrinkSlice(v)...)
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			add(reflect.Zero(v.Type()))
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			add(reflect.Zero(v.Type()))
+			break
+		}
+		cands = append(cands, shrinkStruct(v)...)
+	}
+
+	return cands
+}
+
+// shrinkSlice returns a nil slice, shorter slices and slices with one element shrunk.
+func shrinkSlice(v reflect.Value) []reflect.Value {
+	n := v.Len()
+	cands := []reflect.Value{reflect.Zero(v.Type())}
+	if n > 1 {
+		cands = append(cands, v.Slice(0, n/2))
+	}
+	for i := 0; i < n; i++ {
+		removed := reflect.MakeSlice(v.Type(), 0, n-1)
+		removed = reflect.AppendSlice(removed, v.Slice(0, i))
+		removed = reflect.AppendSlice(removed, v.Slice(i+1, n))
+		cands = append(cands, removed)
+	}
+	for i := 0; i < n; i++ {
+		for _, c := range shrinkCandidates(v.Index(i), "") {
+			elems := reflect.MakeSlice(v.Type(), n, n)
+			reflect.Copy(elems, v)
+			elems.Index(i).Set(c)
+			cands = append(cands, elems)
+		}
+	}
+	return cands
+}
+
+// shrinkStruct returns copies of v with one exported field shrunk.
+func shrinkStruct(v reflect.Value) []reflect.Value {
+	var cands []reflect.Value
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		for _, c := range shrinkCandidates(v.Field(i), field.Tag.Get("autofill")) {
+			copied := reflect.New(typ).Elem()
+			copied.Set(v)
+			copied.Field(i).Set(c)
+			cands = append(cands, copied)
+		}
+	}
+	return cands
+}
+
+// shrinkTagged shrinks a value generated from an autofill tag while keeping it
+// within the tag's constraints. Values from other tags and rules are kept as is.
+func shrinkTagged(v reflect.Value, tag string) []reflect.Value {
+	params := parseTagParams(strings.Split(tag, ","))
+	var cands []reflect.Value
+
+	if minStr, ok := params["min"]; ok && isNumericKind(v.Kind()) && v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+		min, err := strconv.ParseInt(minStr, 10, 64)
+		if err != nil {
+			return nil
+		}
+		var cur int64
+		if v.CanInt() {
+			cur = v.Int()
+		} else {
+			cur = int64(v.Uint())
+		}
+		for _, n := range shrinkInt(cur, min) {
+			cands = append(cands, reflect.ValueOf(n).Convert(v.Type()))
+		}
+		return cands
+	}
+
+	if oneof, ok := params["oneof"]; ok && v.Kind() == reflect.String {
+		for _, opt := range strings.Split(oneof, "|") {
+			if opt == v.String() {
+				break
+			}
+			cands = append(cands, reflect.ValueOf(opt).Convert(v.Type()))
+		}
+		return cands
+	}
+
+	return nil
+}
+
+// shrinkInt returns integers between target and n, closest to target first.
+func shrinkInt(n, target int64) []int64 {
+	if n == target {
+		return nil
+	}
+	cands := []int64{target}
+	if mid := target + (n-target)/2; mid != target && mid != n {
+		cands = append(cands, mid)
+	}
+	step := n - 1
+	if n < target {
+		step = n + 1
+	}
+	if step != target {
+		cands = append(cands, step)
+	}
+	return cands
+}
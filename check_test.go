@@ -0,0 +1,134 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// fakeT records the failure reported by Check.
+type fakeT struct {
+	failure string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.failure = fmt.Sprintf(format, args...)
+}
+
+type checkOrder struct {
+	Customer string
+	Quantity int    `autofill:"min=1,max=100"`
+	Status   string `autofill:"oneof=pending|paid|shipped"`
+	Email    string `autofill:"email"`
+	Items    []string
+	Note     *string
+}
+
+func TestCheck_Passes(t *testing.T) {
+	runs := 0
+	Check(t, func(o checkOrder) bool {
+		runs++
+		return o.Quantity >= 1 && o.Quantity <= 100
+	}, CheckRuns(50))
+
+	if runs != 50 {
+		t.Errorf("expected 50 runs, got %d", runs)
+	}
+}
+
+func TestCheck_ShrinksCounterexample(t *testing.T) {
+	ft := &fakeT{}
+	Check(ft, func(o checkOrder) bool {
+		return o.Quantity < 10
+	}, CheckWith(New().WithSeed(1).WithMode(RandomMode)))
+
+	if ft.failure == "" {
+		t.Fatal("expected property to fail")
+	}
+	if !strings.Contains(ft.failure, "seed 1") {
+		t.Errorf("expected seed in failure, got %s", ft.failure)
+	}
+
+	minimal := strings.SplitN(strings.SplitN(ft.failure, "minimal counterexample: ", 2)[1], "\n", 2)[0]
+	for _, want := range []string{"Customer: ", "Quantity:10 ", "Status:pending ", "Items:[] ", "Note:<nil>"} {
+		if !strings.Contains(minimal, want) {
+			t.Errorf("expected minimal counterexample to contain %q, got %s", want, minimal)
+		}
+	}
+	if strings.Contains(minimal, "Email: ") {
+		t.Errorf("expected Email generated by a tag to be kept, got %s", minimal)
+	}
+}
+
+func TestCheck_ShrinksSlices(t *testing.T) {
+	ft := &fakeT{}
+	Check(ft, func(o checkOrder) bool {
+		return len(o.Items) < 2
+	}, CheckOverrides(Override{"Items": []string{"a", "b", "c", "d"}}))
+
+	if !strings.Contains(ft.failure, "Items:[ ] ") {
+		t.Errorf("expected Items shrunk to two empty strings, got %s", ft.failure)
+	}
+}
+
+func TestCheck_PanicIsFailure(t *testing.T) {
+	ft := &fakeT{}
+	Check(ft, func(o checkOrder) bool {
+		if o.Note != nil {
+			panic("unexpected note")
+		}
+		return true
+	})
+
+	if ft.failure == "" {
+		t.Fatal("expected panicking property to fail")
+	}
+}
+
+func TestCheck_FillError(t *testing.T) {
+	ft := &fakeT{}
+	Check(ft, func(o checkOrder) bool { return true }, CheckOverrides(Override{"Quantity": "many"}))
+
+	if !strings.Contains(ft.failure, "failed to generate value") {
+		t.Errorf("expected generation error, got %s", ft.failure)
+	}
+}
+
+func TestShrinkInt(t *testing.T) {
+	tests := []struct {
+		n, target int64
+		expected  []int64
+	}{
+		{0, 0, nil},
+		{1, 0, []int64{0}},
+		{100, 0, []int64{0, 50, 99}},
+		{-100, 0, []int64{0, -50, -99}},
+		{30, 18, []int64{18, 24, 29}},
+	}
+
+	for _, tt := range tests {
+		got := shrinkInt(tt.n, tt.target)
+		if fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+			t.Errorf("shrinkInt(%d, %d) = %v, expected %v", tt.n, tt.target, got, tt.expected)
+		}
+	}
+}
+
+func TestShrinkCandidates_StringByRune(t *testing.T) {
+	cands := shrinkCandidates(reflect.ValueOf("日本語"), "")
+
+	var got []string
+	for _, c := range cands {
+		if !utf8.ValidString(c.String()) {
+			t.Errorf("expected valid UTF-8, got %q", c.String())
+		}
+		got = append(got, c.String())
+	}
+	if fmt.Sprint(got) != fmt.Sprint([]string{"", "日", "日本"}) {
+		t.Errorf("expected candidates cut by rune, got %q", got)
+	}
+}
@@ -0,0 +1,286 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// CombinationMode selects how Combinations expands field domains.
+type CombinationMode int
+
+const (
+	// Cartesian generates every combination of the enumerated field values.
+	Cartesian CombinationMode = iota
+
+	// Pairwise generates a covering array in which every pair of values of
+	// any two enumerated fields appears at least once. It needs far fewer
+	// elements than Cartesian when there are many fields.
+	Pairwise
+)
+
+// maxRangeDomain is the largest min/max range that is treated as a finite domain.
+const maxRangeDomain = 16
+
+// WithEnum registers the values of an enum type for Combinations.
+// All values must have the same type. Fields of that type are expanded to
+// the registered values.
+//
+//	af := autofill.New().WithEnum(RoleAdmin, RoleMember, RoleGuest)
+func (a *Autofill) WithEnum(values ...interface{}) *Autofill {
+	if len(values) == 0 {
+		panic("WithEnum requires at least one value")
+	}
+	typ := reflect.TypeOf(values[0])
+	for _, v := range values[1:] {
+		if reflect.TypeOf(v) != typ {
+			panic(fmt.Sprintf("WithEnum values must have the same type, got %s and %T", typ, v))
+		}
+	}
+
+	enums := make(map[reflect.Type][]interface{}, len(a.enums)+1)
+	for t, vals := range a.enums {
+		enums[t] = vals
+	}
+	enums[typ] = values
+	a.enums = enums
+	return a
+}
+
+// Combinations fills the slice pointed to by v with one element per combination
+// of the values of its fields with finite domains: oneof tags, bools, min/max
+// ranges of at most 16 values and enums registered with WithEnum.
+// The slice is resized to the number of combinations, and the other fields are
+// filled as in FillSlice. Fields set by overrides are not enumerated.
+//
+// Example:
+//
+//	type Permission struct {
+//	    Role    string `autofill:"oneof=admin|member|guest"`
+//	    CanEdit bool
+//	    Plan    Plan // registered with WithEnum
+//	}
+//
+//	var perms []Permission
+//	af.Combinations(&perms, autofill.Pairwise)
+func (a *Autofill) Combinations(v interface{}, mode CombinationMode, overrides ...Override) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return fmt.Errorf("Combinations requires a pointer to slice, got %T", v)
+	}
+
+	elem := rv.Elem()
+	if elem.Kind() != reflect.Slice {
+		return fmt.Errorf("Combinations requires a pointer to slice, got pointer to %s", elem.Kind())
+	}
+
+	elemType := elem.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("Combinations requires a slice of structs, got slice of %s", elemType.Kind())
+	}
+
+	override, err := a.mergeWithDefaults(overrides)
+	if err != nil {
+		return err
+	}
+	names, domains := a.finiteDomains(elemType, override)
+
+	var rows [][]int
+	if mode == Pairwise {
+		rows = pairwiseRows(domains)
+	} else {
+		rows = cartesianRows(domains)
+	}
+
+	slice := reflect.MakeSlice(elem.Type(), len(rows), len(rows))
+	for i, row := range rows {
+		combo := make(Override, len(names))
+		for p, name := range names {
+			combo[name] = domains[p][row[p]]
+		}
+
+		item := slice.Index(i).Addr().Interface()
+		// Copy overrides, so that appending never writes into the caller's array
+		if err := a.FillWithIndex(item, i, append(append([]Override(nil), overrides...), combo)...); err != nil {
+			return fmt.Errorf("failed to fill combination at index %d: %w", i, err)
+		}
+	}
+
+	elem.Set(slice)
+	return nil
+}
+
+// Combinations is a convenience function that creates a new Autofill instance
+// and fills the given slice with combinations of its enumerated fields.
+func Combinations(v interface{}, mode CombinationMode, overrides ...Override) error {
+	return New().Combinations(v, mode, overrides...)
+}
+
+// finiteDomains returns the names and values of the settable fields of typ
+// with finite domains, skipping fields set by override.
+func (a *Autofill) finiteDomains(typ reflect.Type, override Override) ([]string, [][]interface{}) {
+	var names []string
+	var domains [][]interface{}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if _, ok := override[field.Name]; ok {
+			continue
+		}
+		if values := a.fieldDomain(field); len(values) > 0 {
+			names = append(names, field.Name)
+			domains = append(domains, values)
+		}
+	}
+
+	return names, domains
+}
+
+// fieldDomain returns the finite set of values of field, or nil if it has none.
+func (a *Autofill) fieldDomain(field reflect.StructField) []interface{} {
+	tag := field.Tag.Get("autofill")
+	if tag == "-" {
+		return nil
+	}
+
+	if values, ok := a.enums[field.Type]; ok {
+		return values
+	}
+
+	params := parseTagParams(strings.Split(tag, ","))
+	if oneof, ok := params["oneof"]; ok {
+		options := strings.Split(oneof, "|")
+		values := make([]interface{}, len(options))
+		for i, opt := range options {
+			values[i] = opt
+		}
+		return values
+	}
+
+	minStr, hasMin := params["min"]
+	maxStr, hasMax := params["max"]
+	if hasMin && hasMax {
+		min, errMin := strconv.Atoi(minStr)
+		max, errMax := strconv.Atoi(maxStr)
+		if errMin != nil || errMax != nil || min > max || max-min >= maxRangeDomain {
+			return nil
+		}
+		values := make([]interface{}, 0, max-min+1)
+		for n := min; n <= max; n++ {
+			values = append(values, n)
+		}
+		return values
+	}
+
+	if tag == "" && field.Type.Kind() == reflect.Bool {
+		return []interface{}{false, true}
+	}
+
+	return nil
+}
+
+// cartesianRows returns every combination of value indexes,
+// the first parameter varying slowest.
+func cartesianRows(domains [][]interface{}) [][]int {
+	var rows [][]int
+	row := make([]int, len(domains))
+	for {
+		rows = append(rows, append([]int(nil), row...))
+
+		p := len(domains) - 1
+		for ; p >= 0; p-- {
+			row[p]++
+			if row[p] < len(domains[p]) {
+				break
+			}
+			row[p] = 0
+		}
+		if p < 0 {
+			return rows
+		}
+	}
+}
+
+// pair identifies value va of parameter a combined with value vb of parameter b.
+type pair struct {
+	a, va, b, vb int
+}
+
+// pairwiseRows returns rows covering every pair of values of any two parameters,
+// built greedily: each row starts from the first uncovered pair and then picks,
+// for every other parameter, the value covering the most uncovered pairs.
+func pairwiseRows(domains [][]interface{}) [][]int {
+	if len(domains) < 2 {
+		return cartesianRows(domains)
+	}
+
+	uncovered := make(map[pair]bool)
+	var order []pair
+	for a := range domains {
+		for b := a + 1; b < len(domains); b++ {
+			for va := range domains[a] {
+				for vb := range domains[b] {
+					p := pair{a, va, b, vb}
+					uncovered[p] = true
+					order = append(order, p)
+				}
+			}
+		}
+	}
+
+	var rows [][]int
+	for _, first := range order {
+		if !uncovered[first] {
+			continue
+		}
+
+		row := make([]int, len(domains))
+		assigned := make([]bool, len(domains))
+		row[first.a], row[first.b] = first.va, first.vb
+		assigned[first.a], assigned[first.b] = true, true
+
+		for p := range domains {
+			if assigned[p] {
+				continue
+			}
+			best, bestCount := 0, -1
+			for v := range domains[p] {
+				count := 0
+				for q := range domains {
+					if !assigned[q] {
+						continue
+					}
+					if uncovered[orderedPair(p, v, q, row[q])] {
+						count++
+					}
+				}
+				if count > bestCount {
+					best, bestCount = v, count
+				}
+			}
+			row[p] = best
+			assigned[p] = true
+		}
+
+		for a := range domains {
+			for b := a + 1; b < len(domains); b++ {
+				delete(uncovered, pair{a, row[a], b, row[b]})
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// orderedPair returns the pair of parameters p and q with the lower parameter first.
+func orderedPair(p, vp, q, vq int) pair {
+	if p < q {
+		return pair{p, vp, q, vq}
+	}
+	return pair{q, vq, p, vp}
+}
@@ -0,0 +1,190 @@
+package autofill
+
+import (
+	"fmt"
+	"testing"
+)
+
+type plan string
+
+const (
+	planFree plan = "free"
+	planPro  plan = "pro"
+	planTeam plan = "team"
+)
+
+type permission struct {
+	Role     string `autofill:"oneof=admin|member|guest"`
+	CanEdit  bool
+	Level    int `autofill:"min=1,max=2"`
+	Plan     plan
+	Name     string
+	Internal bool `autofill:"-"`
+}
+
+func TestCombinations_Cartesian(t *testing.T) {
+	var perms []permission
+	err := New().WithEnum(planFree, planPro, planTeam).Combinations(&perms, Cartesian)
+	if err != nil {
+		t.Fatalf("Combinations failed: %v", err)
+	}
+
+	if len(perms) != 3*2*2*3 {
+		t.Fatalf("expected 36 combinations, got %d", len(perms))
+	}
+
+	seen := make(map[string]bool)
+	for i, p := range perms {
+		key := fmt.Sprintf("%s/%v/%d/%s", p.Role, p.CanEdit, p.Level, p.Plan)
+		if seen[key] {
+			t.Errorf("duplicate combination %s", key)
+		}
+		seen[key] = true
+
+		if p.Name == "" {
+			t.Errorf("combination %d: expected Name to be filled", i)
+		}
+		if p.Internal {
+			t.Errorf("combination %d: expected skipped field to stay false", i)
+		}
+	}
+
+	if perms[0].Role != "admin" || perms[len(perms)-1].Role != "guest" {
+		t.Errorf("expected first field to vary slowest, got %s first and %s last", perms[0].Role, perms[len(perms)-1].Role)
+	}
+}
+
+func TestCombinations_Pairwise(t *testing.T) {
+	type flags struct {
+		A string `autofill:"oneof=a1|a2|a3"`
+		B string `autofill:"oneof=b1|b2|b3"`
+		C string `autofill:"oneof=c1|c2|c3"`
+		D string `autofill:"oneof=d1|d2|d3"`
+		E bool
+	}
+
+	var rows []flags
+	if err := Combinations(&rows, Pairwise); err != nil {
+		t.Fatalf("Combinations failed: %v", err)
+	}
+
+	if len(rows) >= 3*3*3*3*2 {
+		t.Errorf("expected pairwise to need fewer rows than the Cartesian product, got %d", len(rows))
+	}
+
+	values := func(f flags) []string {
+		return []string{f.A, f.B, f.C, f.D, fmt.Sprint(f.E)}
+	}
+	domains := [][]string{
+		{"a1", "a2", "a3"}, {"b1", "b2", "b3"}, {"c1", "c2", "c3"}, {"d1", "d2", "d3"}, {"false", "true"},
+	}
+
+	covered := make(map[string]bool)
+	for _, r := range rows {
+		v := values(r)
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				covered[v[i]+"/"+v[j]] = true
+			}
+		}
+	}
+	for i := range domains {
+		for j := i + 1; j < len(domains); j++ {
+			for _, vi := range domains[i] {
+				for _, vj := range domains[j] {
+					if !covered[vi+"/"+vj] {
+						t.Errorf("pair %s/%s is not covered", vi, vj)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCombinations_OverriddenFieldsFixed(t *testing.T) {
+	var perms []permission
+	err := New().WithEnum(planFree, planPro).Combinations(&perms, Cartesian, Override{
+		"Role": "owner",
+	})
+	if err != nil {
+		t.Fatalf("Combinations failed: %v", err)
+	}
+
+	if len(perms) != 2*2*2 {
+		t.Fatalf("expected 8 combinations, got %d", len(perms))
+	}
+	for i, p := range perms {
+		if p.Role != "owner" {
+			t.Errorf("combination %d: expected Role owner, got %s", i, p.Role)
+		}
+	}
+}
+
+func TestCombinations_KeepsCallerOverrides(t *testing.T) {
+	sentinel := Override{"Name": "untouched"}
+	backing := []Override{{"Role": "owner"}, sentinel}
+	overrides := backing[:1]
+
+	var perms []permission
+	if err := New().Combinations(&perms, Cartesian, overrides...); err != nil {
+		t.Fatalf("Combinations failed: %v", err)
+	}
+	if len(backing[1]) != 1 || backing[1]["Name"] != "untouched" {
+		t.Errorf("expected the caller's backing array to be left alone, got %v", backing[1])
+	}
+}
+
+func TestCombinations_NoFiniteFields(t *testing.T) {
+	type plain struct {
+		Name string
+		Age  int
+	}
+
+	var users []plain
+	if err := Combinations(&users, Pairwise); err != nil {
+		t.Fatalf("Combinations failed: %v", err)
+	}
+	if len(users) != 1 {
+		t.Errorf("expected a single element, got %d", len(users))
+	}
+}
+
+func TestCombinations_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{"not a pointer", []permission{}},
+		{"pointer to non-slice", &permission{}},
+		{"slice of non-structs", &[]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Combinations(tt.input, Cartesian); err == nil {
+				t.Error("expected error for invalid input, got nil")
+			}
+		})
+	}
+}
+
+func TestWithEnum_Panics(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []interface{}
+	}{
+		{"no values", nil},
+		{"mixed types", []interface{}{planFree, "pro"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected panic")
+				}
+			}()
+			New().WithEnum(tt.values...)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package autofill
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFill_ConcurrentCallers(t *testing.T) {
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	af := New().WithSeed(42).WithMode(RandomMode).WithClock(func() time.Time { return ref })
+
+	var want TestUser
+	if err := af.FillWithIndex(&want, 3); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				var got TestUser
+				if err := af.FillWithIndex(&got, 3); err != nil {
+					t.Errorf("Fill failed: %v", err)
+					return
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("expected %+v, got %+v", want, got)
+					return
+				}
+
+				users := make([]TestUser, 5)
+				if err := af.FillSlice(&users); err != nil {
+					t.Errorf("FillSlice failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithWorkers(t *testing.T) {
+	af := New().WithWorkers(4)
+	if af.workers != 4 {
+		t.Errorf("expected 4 workers, got %d", af.workers)
+	}
+
+	type address struct {
+		City string
+		Zip  *string
+	}
+	// No floats: edge cases include NaN, which DeepEqual never treats as equal.
+	type Profile struct {
+		ID      int
+		Name    string
+		Tags    []string
+		Created time.Time
+	}
+	type account struct {
+		Profile
+		Home    address
+		Visits  []address
+		Labels  map[string]int
+		Updated time.Time `autofill:"now"`
+	}
+
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fill := func(workers int) []account {
+		accounts := make([]account, 200)
+		err := New().WithSeed(11).WithMode(RandomMode).WithEdgeCases(0.1).
+			WithClock(func() time.Time { return ref }).
+			WithWorkers(workers).
+			FillSlice(&accounts, Override{"Home.City": Shuffle("Tokyo", "Osaka", "Kyoto")})
+		if err != nil {
+			t.Fatalf("FillSlice with %d workers failed: %v", workers, err)
+		}
+		return accounts
+	}
+
+	sequential := fill(1)
+	if sequential[1].Name == "" || sequential[1].Home.City == "" {
+		t.Fatalf("expected filled accounts, got %+v", sequential[1])
+	}
+	for _, workers := range []int{0, 4, 16} {
+		if parallel := fill(workers); !reflect.DeepEqual(sequential, parallel) {
+			t.Errorf("%d workers: expected output identical to sequential fill", workers)
+		}
+	}
+}
+
+func TestFillSlice_WithWorkers_MatchesSequential(t *testing.T) {
+	for _, mode := range []Mode{SequentialMode, RandomMode} {
+		sequential := make([]modeUser, 1000)
+		if err := New().WithSeed(7).WithMode(mode).FillSlice(&sequential, Override{
+			"Email": Seq("user%d@example.com"),
+		}); err != nil {
+			t.Fatalf("FillSlice failed: %v", err)
+		}
+
+		for _, workers := range []int{2, 3, 8, 2000} {
+			parallel := make([]modeUser, 1000)
+			if err := New().WithSeed(7).WithMode(mode).WithWorkers(workers).FillSlice(&parallel, Override{
+				"Email": Seq("user%d@example.com"),
+			}); err != nil {
+				t.Fatalf("FillSlice failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(sequential, parallel) {
+				t.Errorf("mode %v, %d workers: expected output identical to sequential fill", mode, workers)
+			}
+		}
+	}
+}
+
+func TestFillSlice_WithWorkers_ReturnsFirstError(t *testing.T) {
+	users := make([]TestUser, 100)
+	err := New().WithWorkers(4).FillSlice(&users, Override{
+		"Name": SequenceFunc(func(index int) interface{} {
+			if index == 30 || index == 90 {
+				return index
+			}
+			return "name"
+		}),
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "index 30") {
+		t.Errorf("expected error for index 30, got %v", err)
+	}
+}
@@ -1,8 +1,12 @@
 package autofill
 
 import (
+	"encoding/binary"
+	"hash/fnv"
 	"math/rand"
 	"reflect"
+	"strconv"
+	"time"
 )
 
 // Context provides information about the current generation context.
@@ -17,9 +21,25 @@ type Context interface {
 	// Index returns the current index when filling slices (0-based)
 	Index() int
 
-	// Rand returns the random number generator for this context
+	// Rand returns the random number generator for this context, backed by
+	// the Source configured with WithSource.
+	// Each field gets its own stream derived from the seed, the type being
+	// filled, the field path and the index, so values are distinct across
+	// fields and stable when unrelated fields are added or reordered.
 	Rand() *rand.Rand
 
+	// Now returns the reference time for time-related generators
+	Now() time.Time
+
+	// EdgeCase reports whether the current value should be a boundary value,
+	// such as an empty string or the maximum integer. It draws from Rand()
+	// and always returns false unless edge cases are enabled
+	EdgeCase() bool
+
+	// Randomized reports whether values should be drawn from Rand()
+	// instead of being derived from Index()
+	Randomized() bool
+
 	// GetField returns the value of a field by name from the current struct being filled
 	GetField(name string) (interface{}, bool)
 
@@ -28,27 +48,44 @@ type Context interface {
 
 	// FieldName returns the name of the current field being filled
 	FieldName() string
+
+	// Path returns the path of the current field from the root struct,
+	// e.g. "Address.City" or "Tags[1]"
+	Path() string
 }
 
 // context is the internal implementation of Context
 type context struct {
-	locale    string
-	seed      int64
-	index     int
-	rand      *rand.Rand
-	fieldMap  map[string]interface{}
-	structVal interface{}
-	fieldName string
+	locale     string
+	seed       int64
+	index      int
+	fillIndex  int
+	rand       *rand.Rand
+	stream     *rand.Rand
+	source     SourceFunc
+	randomized bool
+	strict     bool
+	edgeRatio  float64
+	now        time.Time
+	structVal  interface{}
+	fieldName  string
+	typeName   string
+	path       string
+	overrides  Override
+	types      Override
+	trace      *Trace
 }
 
-// NewContext creates a new Context with the given parameters
+// NewContext creates a new Context with the given parameters.
+// If r is nil, each field draws from its own stream derived from the seed.
 func newContext(locale string, seed int64, index int, r *rand.Rand) *context {
 	return &context{
-		locale:   locale,
-		seed:     seed,
-		index:    index,
-		rand:     r,
-		fieldMap: make(map[string]interface{}),
+		locale:    locale,
+		seed:      seed,
+		index:     index,
+		fillIndex: index,
+		rand:      r,
+		now:       time.Now(),
 	}
 }
 
@@ -69,13 +106,68 @@ func (c *context) Index() int {
 
 // Rand returns the random number generator
 func (c *context) Rand() *rand.Rand {
-	return c.rand
+	if c.rand != nil {
+		return c.rand
+	}
+	if c.stream == nil {
+		source := c.source
+		if source == nil {
+			source = PCG()
+		}
+		c.stream = newRand(source(c.streamSeed()))
+	}
+	return c.stream
+}
+
+// streamSeed hashes the seed, type name, field path and fill index into the
+// seed of the current field's random stream.
+func (c *context) streamSeed() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(c.seed))
+	h.Write(buf[:])
+	h.Write([]byte(c.typeName))
+	h.Write([]byte{0})
+	h.Write([]byte(c.path))
+	h.Write([]byte{0})
+	binary.LittleEndian.PutUint64(buf[:], uint64(c.fillIndex))
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+// Now returns the reference time
+func (c *context) Now() time.Time {
+	return c.now
+}
+
+// EdgeCase reports whether the current value should be a boundary value
+func (c *context) EdgeCase() bool {
+	if c.edgeRatio <= 0 {
+		return false
+	}
+	return c.Rand().Float64() < c.edgeRatio
+}
+
+// Randomized reports whether values should be drawn from the random number generator
+func (c *context) Randomized() bool {
+	return c.randomized
 }
 
 // GetField returns the value of a field by name
 func (c *context) GetField(name string) (interface{}, bool) {
-	val, ok := c.fieldMap[name]
-	return val, ok
+	val := reflect.ValueOf(c.structVal)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	field := val.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, false
+	}
+	return field.Interface(), true
 }
 
 // GetStruct returns the struct being filled
@@ -88,27 +180,16 @@ func (c *context) FieldName() string {
 	return c.fieldName
 }
 
-// withStruct creates a new context with the given struct value
+// Path returns the path of the current field
+func (c *context) Path() string {
+	return c.path
+}
+
+// withStruct creates a new context with the given struct value.
+// GetField reads the fields of v as they are being filled, so v should be a pointer.
 func (c *context) withStruct(v interface{}) *context {
 	newCtx := *c
 	newCtx.structVal = v
-	newCtx.fieldMap = make(map[string]interface{})
-
-	// Populate field map
-	val := reflect.ValueOf(v)
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
-	}
-	if val.Kind() == reflect.Struct {
-		typ := val.Type()
-		for i := 0; i < val.NumField(); i++ {
-			field := val.Field(i)
-			if field.IsValid() && field.CanInterface() {
-				newCtx.fieldMap[typ.Field(i).Name] = field.Interface()
-			}
-		}
-	}
-
 	return &newCtx
 }
 
@@ -116,6 +197,13 @@ func (c *context) withStruct(v interface{}) *context {
 func (c *context) withFieldName(name string) *context {
 	newCtx := *c
 	newCtx.fieldName = name
+	if c.path == "" {
+		newCtx.path = name
+	} else {
+		newCtx.path = c.path + "." + name
+	}
+	newCtx.stream = nil
+	newCtx.overrides = nil
 	return &newCtx
 }
 
@@ -125,3 +213,47 @@ func (c *context) withIndex(index int) *context {
 	newCtx.index = index
 	return &newCtx
 }
+
+// withElement creates a new context for the element at index i of the
+// current slice field
+func (c *context) withElement(i int) *context {
+	newCtx := c.withIndex(i)
+	newCtx.path = c.path + "[" + strconv.Itoa(i) + "]"
+	newCtx.stream = nil
+	newCtx.overrides = nil
+	return newCtx
+}
+
+// withKey creates a new context for the entry with the given key of the
+// current map field, at index i in key order
+func (c *context) withKey(i int, key string) *context {
+	newCtx := c.withIndex(i)
+	newCtx.path = c.path + "[" + key + "]"
+	newCtx.stream = nil
+	newCtx.overrides = nil
+	return newCtx
+}
+
+// withOverrides creates a new context with the overrides for the current value.
+// Keys are relative to the value, e.g. "City" for an Address field.
+func (c *context) withOverrides(overrides Override) *context {
+	newCtx := *c
+	newCtx.overrides = overrides
+	return &newCtx
+}
+
+// withType creates a new context for filling a value of the given root type
+func (c *context) withType(typ reflect.Type) *context {
+	newCtx := *c
+	newCtx.typeName = typeName(typ)
+	newCtx.stream = nil
+	return &newCtx
+}
+
+// typeName returns the package-qualified name of typ
+func typeName(typ reflect.Type) string {
+	if typ.Name() == "" {
+		return typ.String()
+	}
+	return typ.PkgPath() + "." + typ.Name()
+}
@@ -2,6 +2,7 @@ package autofill
 
 import (
 	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -184,3 +185,112 @@ func TestContext_ImmutabilityChain(t *testing.T) {
 		t.Errorf("ctx3 field name should be Field1, got %s", ctx3.FieldName())
 	}
 }
+
+func TestContext_Path(t *testing.T) {
+	ctx := newContext("en_US", 12345, 0, nil)
+
+	nested := ctx.withFieldName("Address").withFieldName("City")
+	if nested.Path() != "Address.City" {
+		t.Errorf("expected path Address.City, got %s", nested.Path())
+	}
+
+	elem := ctx.withFieldName("Tags").withElement(2)
+	if elem.Path() != "Tags[2]" {
+		t.Errorf("expected path Tags[2], got %s", elem.Path())
+	}
+	if elem.Index() != 2 {
+		t.Errorf("expected index 2, got %d", elem.Index())
+	}
+}
+
+func TestContext_RandStreams(t *testing.T) {
+	first := func(ctx *context) int64 { return ctx.Rand().Int63() }
+
+	ctx := newContext("en_US", 12345, 0, nil).withType(reflect.TypeOf(TestUser{}))
+
+	a := first(ctx.withFieldName("Name"))
+	if a != first(ctx.withFieldName("Name")) {
+		t.Error("expected same stream for same field path")
+	}
+	if a == first(ctx.withFieldName("Email")) {
+		t.Error("expected different streams for different field paths")
+	}
+
+	other := newContext("en_US", 12345, 1, nil).withType(reflect.TypeOf(TestUser{}))
+	if a == first(other.withFieldName("Name")) {
+		t.Error("expected different streams for different indexes")
+	}
+
+	reseeded := newContext("en_US", 54321, 0, nil).withType(reflect.TypeOf(TestUser{}))
+	if a == first(reseeded.withFieldName("Name")) {
+		t.Error("expected different streams for different seeds")
+	}
+}
+
+func TestFill_DistinctFieldStreams(t *testing.T) {
+	type IDs struct {
+		ID       string `autofill:"uuid"`
+		ParentID string `autofill:"uuid"`
+		Price    float64
+		Cost     float64
+	}
+
+	var ids IDs
+	if err := New().WithSeed(1).Fill(&ids); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if ids.ID == ids.ParentID {
+		t.Errorf("expected distinct UUIDs, got %s twice", ids.ID)
+	}
+	if ids.Price == ids.Cost {
+		t.Errorf("expected distinct floats, got %f twice", ids.Price)
+	}
+}
+
+// fillRecordV1 and fillRecordV2 declare two versions of the same named type,
+// as if a field had been added and the fields reordered between releases.
+func fillRecordV1(t *testing.T) (string, string, float64) {
+	type Record struct {
+		Name  string
+		Email string `autofill:"email"`
+		Score float64
+	}
+
+	var r Record
+	if err := New().WithSeed(9).WithMode(RandomMode).FillWithIndex(&r, 3); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	return r.Name, r.Email, r.Score
+}
+
+func fillRecordV2(t *testing.T) (string, string, float64) {
+	type Record struct {
+		Added string `autofill:"uuid"`
+		Score float64
+		Name  string
+		Extra int
+		Email string `autofill:"email"`
+	}
+
+	var r Record
+	if err := New().WithSeed(9).WithMode(RandomMode).FillWithIndex(&r, 3); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	return r.Name, r.Email, r.Score
+}
+
+func TestFill_StableWhenFieldsAdded(t *testing.T) {
+	name1, email1, score1 := fillRecordV1(t)
+	name2, email2, score2 := fillRecordV2(t)
+
+	if name1 != name2 {
+		t.Errorf("expected Name to be stable, got %s and %s", name1, name2)
+	}
+	if email1 != email2 {
+		t.Errorf("expected Email to be stable, got %s and %s", email1, email2)
+	}
+	if score1 != score2 {
+		t.Errorf("expected Score to be stable, got %f and %f", score1, score2)
+	}
+}
@@ -0,0 +1,82 @@
+package autofill
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// edgeStrings are boundary strings emitted in edge case mode.
+var edgeStrings = []string{
+	"",
+	" ",
+	strings.Repeat("a", 1024),
+	"日本語のテキスト",
+	"한국어 텍스트",
+	"😀👍🏽🇯🇵👨‍👩‍👧",
+	"line\nbreak\ttab",
+	"'\"<>&;--",
+}
+
+// edgeTimes are boundary times emitted in edge case mode.
+var edgeTimes = []time.Time{
+	{},
+	time.Unix(0, 0).UTC(),
+	time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+}
+
+// generateEdgeCase returns a boundary value for typ if the context requests one.
+// The values respect the exact width of the type, e.g. math.MaxInt8 for int8.
+// Values with overrides for their fields or elements are never boundary values,
+// since a nil or empty value would drop those overrides.
+func (a *Autofill) generateEdgeCase(typ reflect.Type, ctx *context) (interface{}, bool) {
+	if len(ctx.overrides) > 0 {
+		return nil, false
+	}
+
+	var cands []interface{}
+
+	switch typ.Kind() {
+	case reflect.String:
+		cands = make([]interface{}, len(edgeStrings))
+		for i, s := range edgeStrings {
+			cands[i] = s
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := typ.Bits()
+		max := int64(1)<<(bits-1) - 1
+		cands = []interface{}{-max - 1, max, int64(0), int64(-1), int64(1)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		max := uint64(math.MaxUint64) >> (64 - typ.Bits())
+		cands = []interface{}{uint64(0), uint64(1), max}
+	case reflect.Float32:
+		cands = []interface{}{math.NaN(), math.Inf(1), math.Inf(-1), 0.0, math.Copysign(0, -1),
+			float64(math.MaxFloat32), -float64(math.MaxFloat32), float64(math.SmallestNonzeroFloat32)}
+	case reflect.Float64:
+		cands = []interface{}{math.NaN(), math.Inf(1), math.Inf(-1), 0.0, math.Copysign(0, -1),
+			math.MaxFloat64, -math.MaxFloat64, math.SmallestNonzeroFloat64}
+	case reflect.Ptr:
+		cands = []interface{}{reflect.Zero(typ).Interface()}
+	case reflect.Slice:
+		cands = []interface{}{reflect.Zero(typ).Interface(), reflect.MakeSlice(typ, 0, 0).Interface()}
+	case reflect.Map:
+		cands = []interface{}{reflect.Zero(typ).Interface(), reflect.MakeMap(typ).Interface()}
+	case reflect.Struct:
+		if typ != reflect.TypeOf(time.Time{}) {
+			return nil, false
+		}
+		cands = make([]interface{}, 0, len(edgeTimes)+1)
+		for _, t := range edgeTimes {
+			cands = append(cands, t)
+		}
+		cands = append(cands, ctx.Now())
+	default:
+		return nil, false
+	}
+
+	if !ctx.EdgeCase() {
+		return nil, false
+	}
+	return cands[ctx.Rand().Intn(len(cands))], true
+}
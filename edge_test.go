@@ -0,0 +1,180 @@
+package autofill
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type edgeRecord struct {
+	Name    string
+	Small   int8
+	Count   int
+	Size    uint16
+	Ratio   float32
+	Score   float64
+	At      time.Time
+	Note    *string
+	Tags    []string
+	Age     int    `autofill:"min=18,max=65"`
+	Status  string `autofill:"oneof=active|inactive|pending"`
+	Enabled bool
+}
+
+func TestWithEdgeCases(t *testing.T) {
+	tests := []struct {
+		ratio    float64
+		expected float64
+	}{
+		{0.25, 0.25},
+		{-1, 0},
+		{2, 1},
+	}
+
+	for _, tt := range tests {
+		af := New().WithEdgeCases(tt.ratio)
+		if af.edges != tt.expected {
+			t.Errorf("WithEdgeCases(%v): expected ratio %v, got %v", tt.ratio, tt.expected, af.edges)
+		}
+	}
+}
+
+func TestEdgeCases_AlwaysBoundary(t *testing.T) {
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := make([]edgeRecord, 200)
+	err := New().WithSeed(3).WithEdgeCases(1).WithClock(func() time.Time { return ref }).FillSlice(&records)
+	if err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	isEdgeString := func(s string) bool {
+		for _, e := range edgeStrings {
+			if s == e {
+				return true
+			}
+		}
+		return false
+	}
+
+	var sawNaN, sawNilTags, sawEmptyTags bool
+	for i, r := range records {
+		if !isEdgeString(r.Name) {
+			t.Errorf("record %d: expected edge case Name, got %q", i, r.Name)
+		}
+		switch r.Small {
+		case math.MinInt8, math.MaxInt8, 0, -1, 1:
+		default:
+			t.Errorf("record %d: expected edge case Small, got %d", i, r.Small)
+		}
+		switch r.Count {
+		case math.MinInt, math.MaxInt, 0, -1, 1:
+		default:
+			t.Errorf("record %d: expected edge case Count, got %d", i, r.Count)
+		}
+		if r.Size != 0 && r.Size != 1 && r.Size != math.MaxUint16 {
+			t.Errorf("record %d: expected edge case Size, got %d", i, r.Size)
+		}
+		if r.Note != nil {
+			t.Errorf("record %d: expected nil Note, got %v", i, *r.Note)
+		}
+		if r.Age != 18 && r.Age != 65 {
+			t.Errorf("record %d: expected Age at its bounds, got %d", i, r.Age)
+		}
+		if r.Status != "active" && r.Status != "pending" {
+			t.Errorf("record %d: expected first or last Status, got %s", i, r.Status)
+		}
+		if !r.At.IsZero() && !r.At.Equal(ref) && r.At.Year() != 9999 && r.At.Unix() != 0 {
+			t.Errorf("record %d: expected edge case At, got %v", i, r.At)
+		}
+
+		sawNaN = sawNaN || math.IsNaN(r.Score)
+		sawNilTags = sawNilTags || r.Tags == nil
+		sawEmptyTags = sawEmptyTags || (r.Tags != nil && len(r.Tags) == 0)
+	}
+
+	if !sawNaN {
+		t.Error("expected at least one NaN Score")
+	}
+	if !sawNilTags || !sawEmptyTags {
+		t.Errorf("expected nil and empty Tags, got nil=%v empty=%v", sawNilTags, sawEmptyTags)
+	}
+}
+
+func TestEdgeCases_Ratio(t *testing.T) {
+	records := make([]edgeRecord, 500)
+	if err := New().WithSeed(5).WithEdgeCases(0.2).FillSlice(&records); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	edges := 0
+	for _, r := range records {
+		if r.Note == nil {
+			edges++
+		}
+	}
+
+	if edges < 50 || edges > 150 {
+		t.Errorf("expected about 100 nil Notes for ratio 0.2, got %d", edges)
+	}
+}
+
+func TestEdgeCases_DisabledByDefault(t *testing.T) {
+	records1 := make([]edgeRecord, 50)
+	records2 := make([]edgeRecord, 50)
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return ref }
+
+	if err := New().WithSeed(5).WithClock(clock).FillSlice(&records1); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+	if err := New().WithSeed(5).WithClock(clock).WithEdgeCases(0).FillSlice(&records2); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(records1, records2) {
+		t.Error("expected ratio 0 to leave generation unchanged")
+	}
+	for i, r := range records1 {
+		if r.Note == nil {
+			t.Errorf("record %d: expected non-nil Note without edge cases", i)
+		}
+	}
+}
+
+func TestEdgeCases_NestedOverrides(t *testing.T) {
+	type item struct{ Price int }
+	type address struct{ City string }
+	type order struct {
+		Items []item
+		Home  *address
+		Notes map[string]string
+	}
+
+	orders := make([]order, 50)
+	err := New().WithSeed(1).WithEdgeCases(1).FillSlice(&orders, Override{
+		"Items[*].Price": 42,
+		"Home.City":      "Tokyo",
+		"Notes[a]":       "b",
+	})
+	if err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	for i, o := range orders {
+		if len(o.Items) == 0 {
+			t.Fatalf("order %d: expected Items to keep their overrides, got %v", i, o.Items)
+		}
+		for _, it := range o.Items {
+			if it.Price != 42 {
+				t.Errorf("order %d: expected Price 42, got %d", i, it.Price)
+			}
+		}
+		if o.Home == nil || o.Home.City != "Tokyo" {
+			t.Errorf("order %d: expected Home.City Tokyo, got %+v", i, o.Home)
+		}
+		if o.Notes["a"] != "b" {
+			t.Errorf("order %d: expected Notes[a] b, got %v", i, o.Notes)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Package entadapter persists values filled by autofill through the mutation
+// API that ent generates, so test data doesn't have to be copied field by
+// field into client.User.Create().SetName(...).
+//
+// The adapter uses reflection on the generated code and does not import ent:
+// for an entity User it calls client.User.Create(), the SetXxx method of every
+// field, SetXxx or AddXxx for every loaded edge, and finally Save.
+//
+//	users := autofill.NewFactory[ent.User](nil).
+//	    WithPersister(entadapter.New(client)).
+//	    WithDefaults(entadapter.Defaults[ent.User](autofill.Override{
+//	        "Email": autofill.Seq("user%d@example.com"),
+//	    }))
+//
+//	user, err := users.Create(ctx)
+//
+// Entities in Edges without an ID are created first, and the whole graph is
+// created inside a transaction started with client.Tx. Edges that refer back
+// to an entity still being created, e.g. the posts of a new post's new
+// author, are skipped; the relation is set when that entity is created. Pass an *ent.Tx to New
+// to create entities inside a transaction of your own.
+package entadapter
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/m1a9s9a4/autofill"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Persister is an autofill.Persister that creates ent entities.
+type Persister struct {
+	client reflect.Value
+}
+
+// New creates a Persister that creates entities with client, an *ent.Client
+// or an *ent.Tx. It panics if client is not a pointer to a struct.
+func New(client interface{}) *Persister {
+	v := reflect.ValueOf(client)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("entadapter: New requires an *ent.Client or *ent.Tx, got %T", client))
+	}
+	return &Persister{client: v}
+}
+
+// Persist creates the entity pointed to by v and the entities in its edges,
+// and writes the created entity, including its ID, back into v.
+// The loaded edges of v are kept.
+func (p *Persister) Persist(ctx context.Context, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("entadapter: Persist requires a pointer to an entity, got %T", v)
+	}
+
+	txMethod := p.client.MethodByName("Tx")
+	if !isTxMethod(txMethod) {
+		return create(ctx, p.client, rv, creating{})
+	}
+
+	out := txMethod.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err := asError(out[1]); err != nil {
+		return fmt.Errorf("entadapter: failed to start transaction: %w", err)
+	}
+	tx := out[0]
+	if err := create(ctx, tx, rv, creating{}); err != nil {
+		if rbErr := asError(tx.MethodByName("Rollback").Call(nil)[0]); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+	if err := asError(tx.MethodByName("Commit").Call(nil)[0]); err != nil {
+		return fmt.Errorf("entadapter: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// creating holds the addresses of the entities being created.
+type creating map[uintptr]bool
+
+// create creates the entity pointed to by ptr and its edges with client.
+func create(ctx context.Context, client, ptr reflect.Value, pending creating) error {
+	pending[ptr.Pointer()] = true
+	defer delete(pending, ptr.Pointer())
+
+	entity := ptr.Elem()
+	typ := entity.Type()
+
+	entityClient := client.Elem().FieldByName(typ.Name())
+	if !entityClient.IsValid() || !entityClient.MethodByName("Create").IsValid() {
+		return fmt.Errorf("entadapter: %s has no client for %s", client.Type(), typ)
+	}
+	builder := entityClient.MethodByName("Create").Call(nil)[0]
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Name == "Edges" {
+			continue
+		}
+		value := entity.Field(i)
+		if (field.Name == "ID" || isEdgeKey(typ, field.Name)) && value.IsZero() {
+			continue
+		}
+		if err := call(builder, "Set"+field.Name, value); err != nil {
+			return fmt.Errorf("entadapter: failed to set %s.%s: %w", typ.Name(), field.Name, err)
+		}
+	}
+
+	edges := entity.FieldByName("Edges")
+	if edges.IsValid() && edges.Kind() == reflect.Struct {
+		if err := createEdges(ctx, client, builder, edges, pending); err != nil {
+			return fmt.Errorf("entadapter: failed to create edges of %s: %w", typ.Name(), err)
+		}
+	}
+
+	out := builder.MethodByName("Save").Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err := asError(out[1]); err != nil {
+		return fmt.Errorf("entadapter: failed to create %s: %w", typ.Name(), err)
+	}
+
+	var loaded reflect.Value
+	if edges.IsValid() {
+		loaded = reflect.New(edges.Type()).Elem()
+		loaded.Set(edges)
+	}
+	entity.Set(out[0].Elem())
+	if loaded.IsValid() {
+		entity.FieldByName("Edges").Set(loaded)
+	}
+	return nil
+}
+
+// createEdges creates the entities without an ID in edges and adds them to builder
+// with its SetXxx method for unique edges and its AddXxx method for the others.
+// Entities in pending are left out, since they are not created yet.
+func createEdges(ctx context.Context, client, builder, edges reflect.Value, pending creating) error {
+	for i := 0; i < edges.NumField(); i++ {
+		field := edges.Type().Field(i)
+		value := edges.Field(i)
+		if !field.IsExported() || value.IsZero() {
+			continue
+		}
+
+		switch {
+		case isEntity(field.Type):
+			if pending[value.Pointer()] {
+				continue
+			}
+			if err := createIfNew(ctx, client, value, pending); err != nil {
+				return err
+			}
+			if err := call(builder, "Set"+field.Name, value); err != nil {
+				return fmt.Errorf("failed to set %s: %w", field.Name, err)
+			}
+		case field.Type.Kind() == reflect.Slice && isEntity(field.Type.Elem()):
+			added := reflect.MakeSlice(field.Type, 0, value.Len())
+			for j := 0; j < value.Len(); j++ {
+				elem := value.Index(j)
+				if !elem.IsNil() && pending[elem.Pointer()] {
+					continue
+				}
+				if err := createIfNew(ctx, client, elem, pending); err != nil {
+					return err
+				}
+				added = reflect.Append(added, elem)
+			}
+			method := builder.MethodByName("Add" + field.Name)
+			if !method.IsValid() || !method.Type().IsVariadic() {
+				return fmt.Errorf("%s has no method Add%s", builder.Type(), field.Name)
+			}
+			method.CallSlice([]reflect.Value{added})
+		}
+	}
+	return nil
+}
+
+// createIfNew creates the entity pointed to by ptr if it is not nil and has no ID.
+func createIfNew(ctx context.Context, client, ptr reflect.Value, pending creating) error {
+	if ptr.IsNil() {
+		return nil
+	}
+	if id := ptr.Elem().FieldByName("ID"); id.IsValid() && !id.IsZero() {
+		return nil
+	}
+	return create(ctx, client, ptr, pending)
+}
+
+// call calls the setter name of builder with value, dereferencing or converting
+// value to the parameter type. Missing setters and nil pointers are skipped.
+func call(builder reflect.Value, name string, value reflect.Value) error {
+	method := builder.MethodByName(name)
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().IsVariadic() {
+		return nil
+	}
+	param := method.Type().In(0)
+	if value.Kind() == reflect.Ptr && param.Kind() != reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	switch {
+	case value.Type().AssignableTo(param):
+	case value.Type().ConvertibleTo(param):
+		value = value.Convert(param)
+	default:
+		return fmt.Errorf("cannot pass %s to %s(%s)", value.Type(), name, param)
+	}
+	method.Call([]reflect.Value{value})
+	return nil
+}
+
+// isEntity reports whether typ is a pointer to a struct with an ID field.
+func isEntity(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	_, ok := typ.Elem().FieldByName("ID")
+	return ok
+}
+
+// isEdgeKey reports whether the field name of the entity typ holds the ID of
+// one of its edges, e.g. AuthorID for Edges.Author. A zero key is left unset,
+// so that an optional edge stays empty instead of referring to ID 0.
+func isEdgeKey(typ reflect.Type, name string) bool {
+	edges, ok := typ.FieldByName("Edges")
+	if !ok || edges.Type.Kind() != reflect.Struct || !strings.HasSuffix(name, "ID") {
+		return false
+	}
+	edge, ok := edges.Type.FieldByName(strings.TrimSuffix(name, "ID"))
+	return ok && isEntity(edge.Type)
+}
+
+// isTxMethod reports whether method is like ent's Client.Tx(ctx) (*Tx, error).
+func isTxMethod(method reflect.Value) bool {
+	if !method.IsValid() {
+		return false
+	}
+	typ := method.Type()
+	return typ.NumIn() == 1 && typ.In(0) == contextType &&
+		typ.NumOut() == 2 && typ.Out(1) == errorType
+}
+
+// asError returns the error held by v, which must be of type error.
+func asError(v reflect.Value) error {
+	if v.Type() != errorType || v.IsNil() {
+		return nil
+	}
+	return v.Interface().(error)
+}
+
+// Defaults returns overrides for the ent entity T that leave its ID to the
+// database and its edges and their keys, e.g. AuthorID for Edges.Author, empty,
+// merged with overrides. ent edges usually refer back to the entity, so
+// filling them would never end.
+// Set an edge explicitly, e.g. {"Edges.Posts": posts}, to create it along
+// with the entity.
+func Defaults[T any](overrides ...autofill.Override) autofill.Override {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	defaults := autofill.Override{}
+	if typ.Kind() != reflect.Struct {
+		return defaults
+	}
+	if id, ok := typ.FieldByName("ID"); ok {
+		defaults["ID"] = reflect.Zero(id.Type).Interface()
+	}
+	if edges, ok := typ.FieldByName("Edges"); ok && edges.Type.Kind() == reflect.Struct {
+		for i := 0; i < edges.Type.NumField(); i++ {
+			edge := edges.Type.Field(i)
+			if !edge.IsExported() {
+				continue
+			}
+			defaults["Edges."+edge.Name] = reflect.Zero(edge.Type).Interface()
+			if key, ok := typ.FieldByName(edge.Name + "ID"); ok && isEdgeKey(typ, key.Name) {
+				defaults[key.Name] = reflect.Zero(key.Type).Interface()
+			}
+		}
+	}
+	for _, override := range overrides {
+		for k, v := range override {
+			defaults[k] = v
+		}
+	}
+	return defaults
+}
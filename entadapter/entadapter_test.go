@@ -0,0 +1,281 @@
+package entadapter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/m1a9s9a4/autofill"
+)
+
+// The types below mirror the code ent generates for a schema with users and
+// posts, where a user has many posts and a post has an author. They store
+// entities in memory and support transactions.
+
+type store struct {
+	nextID int
+	users  []*User
+	posts  []*Post
+}
+
+type config struct {
+	store *store
+	log   *[]string
+}
+
+type Role string
+
+type User struct {
+	config
+	ID       int    `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Nickname *string
+	Role     Role
+	Edges    UserEdges
+}
+
+type UserEdges struct {
+	Posts       []*Post
+	loadedTypes [1]bool
+}
+
+type Post struct {
+	config
+	ID       int
+	Title    string
+	AuthorID int
+	Edges    PostEdges
+}
+
+type PostEdges struct {
+	Author *User
+}
+
+type Client struct {
+	config
+	User *UserClient
+	Post *PostClient
+}
+
+func NewClient() *Client {
+	c := config{store: &store{}, log: new([]string)}
+	return &Client{config: c, User: &UserClient{c}, Post: &PostClient{c}}
+}
+
+func (c *Client) Tx(ctx context.Context) (*Tx, error) {
+	staged := *c.store
+	cfg := config{store: &staged, log: c.log}
+	*c.log = append(*c.log, "begin")
+	return &Tx{config: cfg, client: c, User: &UserClient{cfg}, Post: &PostClient{cfg}}, nil
+}
+
+type Tx struct {
+	config
+	client *Client
+	User   *UserClient
+	Post   *PostClient
+}
+
+func (tx *Tx) Commit() error {
+	*tx.client.store = *tx.store
+	*tx.log = append(*tx.log, "commit")
+	return nil
+}
+
+func (tx *Tx) Rollback() error {
+	*tx.log = append(*tx.log, "rollback")
+	return nil
+}
+
+type UserClient struct{ config }
+
+func (c *UserClient) Create() *UserCreate { return &UserCreate{config: c.config} }
+
+type UserCreate struct {
+	config
+	id       int
+	name     string
+	nickname *string
+	role     Role
+	posts    []*Post
+}
+
+func (uc *UserCreate) SetID(id int) *UserCreate                  { uc.id = id; return uc }
+func (uc *UserCreate) SetName(s string) *UserCreate              { uc.name = s; return uc }
+func (uc *UserCreate) SetNickname(s string) *UserCreate          { uc.nickname = &s; return uc }
+func (uc *UserCreate) SetRole(r Role) *UserCreate                { uc.role = r; return uc }
+func (uc *UserCreate) AddPosts(p ...*Post) *UserCreate           { uc.posts = append(uc.posts, p...); return uc }
+func (uc *UserCreate) SetNillableNickname(s *string) *UserCreate { uc.nickname = s; return uc }
+
+func (uc *UserCreate) Save(ctx context.Context) (*User, error) {
+	if uc.name == "" {
+		return nil, errors.New("missing required field \"User.name\"")
+	}
+	id := uc.id
+	if id == 0 {
+		uc.store.nextID++
+		id = uc.store.nextID
+	}
+	u := &User{config: uc.config, ID: id, Name: uc.name, Nickname: uc.nickname, Role: uc.role}
+	for _, p := range uc.posts {
+		p.AuthorID = id
+	}
+	uc.store.users = append(uc.store.users, u)
+	*uc.log = append(*uc.log, "create user "+u.Name)
+	return u, nil
+}
+
+type PostClient struct{ config }
+
+func (c *PostClient) Create() *PostCreate { return &PostCreate{config: c.config} }
+
+type PostCreate struct {
+	config
+	title    string
+	authorID int
+}
+
+func (pc *PostCreate) SetTitle(s string) *PostCreate  { pc.title = s; return pc }
+func (pc *PostCreate) SetAuthorID(id int) *PostCreate { pc.authorID = id; return pc }
+func (pc *PostCreate) SetAuthor(u *User) *PostCreate  { return pc.SetAuthorID(u.ID) }
+
+func (pc *PostCreate) Save(ctx context.Context) (*Post, error) {
+	pc.store.nextID++
+	p := &Post{config: pc.config, ID: pc.store.nextID, Title: pc.title, AuthorID: pc.authorID}
+	pc.store.posts = append(pc.store.posts, p)
+	*pc.log = append(*pc.log, "create post "+p.Title)
+	return p, nil
+}
+
+func TestPersister_Persist(t *testing.T) {
+	client := NewClient()
+	users := autofill.NewFactory[User](nil).
+		WithPersister(New(client)).
+		WithDefaults(Defaults[User](autofill.Override{"Role": Role("admin")}))
+
+	u, err := users.Create(context.Background())
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if u.ID != 1 || u.Name == "" || u.Role != "admin" || u.Nickname == nil {
+		t.Errorf("unexpected user %+v", u)
+	}
+	if len(client.store.users) != 1 || client.store.users[0].Name != u.Name {
+		t.Errorf("expected the user to be committed, got %+v", client.store.users)
+	}
+	if got := strings.Join(*client.log, ", "); got != "begin, create user "+u.Name+", commit" {
+		t.Errorf("unexpected log %q", got)
+	}
+}
+
+func TestPersister_Edges(t *testing.T) {
+	client := NewClient()
+	p := New(client)
+
+	author := &User{Name: "Ada"}
+	post := &Post{Title: "Hello", Edges: PostEdges{Author: author}}
+	if err := p.Persist(context.Background(), post); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	if author.ID != 1 || post.ID != 2 || post.AuthorID != 1 {
+		t.Errorf("expected the author to be created before the post, got %+v and %+v", author, post)
+	}
+	if post.Edges.Author != author {
+		t.Error("expected the loaded edges to be kept")
+	}
+
+	posts := autofill.NewFactory[Post](nil).WithDefaults(Defaults[Post]())
+	user := User{Name: "Grace", Edges: UserEdges{Posts: []*Post{posts.MustBuild(), posts.MustBuild()}}}
+	if err := p.Persist(context.Background(), &user); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	for _, post := range user.Edges.Posts {
+		if post.ID == 0 || post.AuthorID != user.ID {
+			t.Errorf("expected post of user %d, got %+v", user.ID, post)
+		}
+	}
+}
+
+func TestPersister_CyclicEdges(t *testing.T) {
+	client := NewClient()
+	p := New(client)
+
+	post := &Post{Title: "Hello"}
+	author := &User{Name: "Ada", Edges: UserEdges{Posts: []*Post{post, {Title: "World"}}}}
+	post.Edges.Author = author
+	if err := p.Persist(context.Background(), post); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	if author.ID == 0 || post.ID == 0 || post.AuthorID != author.ID {
+		t.Errorf("expected the author to be created once before the post, got %+v and %+v", author, post)
+	}
+	if len(client.store.users) != 1 || len(client.store.posts) != 2 {
+		t.Errorf("expected 1 user and 2 posts, got %d and %d", len(client.store.users), len(client.store.posts))
+	}
+	if got := strings.Join(*client.log, ", "); got != "begin, create post World, create user Ada, create post Hello, commit" {
+		t.Errorf("unexpected log %q", got)
+	}
+}
+
+func TestPersister_Rollback(t *testing.T) {
+	client := NewClient()
+	p := New(client)
+
+	post := &Post{Title: "Hello", Edges: PostEdges{Author: &User{}}}
+	err := p.Persist(context.Background(), post)
+	if err == nil || !strings.Contains(err.Error(), "User.name") {
+		t.Fatalf("expected a missing name error, got %v", err)
+	}
+	if len(client.store.users) != 0 || len(client.store.posts) != 0 {
+		t.Errorf("expected nothing to be committed, got %+v", client.store)
+	}
+	if got := strings.Join(*client.log, ", "); got != "begin, rollback" {
+		t.Errorf("unexpected log %q", got)
+	}
+}
+
+func TestPersister_Tx(t *testing.T) {
+	client := NewClient()
+	tx, _ := client.Tx(context.Background())
+
+	u := &User{Name: "Ada"}
+	if err := New(tx).Persist(context.Background(), u); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	if len(client.store.users) != 0 {
+		t.Error("expected the user to wait for the transaction to be committed")
+	}
+	tx.Commit()
+	if len(client.store.users) != 1 {
+		t.Error("expected the user to be committed with the transaction")
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	defaults := Defaults[User](autofill.Override{"Name": "Ada"})
+	for _, key := range []string{"ID", "Edges.Posts", "Name"} {
+		if _, ok := defaults[key]; !ok {
+			t.Errorf("expected key %q in %v", key, defaults)
+		}
+	}
+	if _, ok := defaults["Edges.loadedTypes"]; ok {
+		t.Error("expected unexported edges to be skipped")
+	}
+
+	var u User
+	if err := autofill.Fill(&u, defaults); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if u.ID != 0 || u.Edges.Posts != nil || u.Name != "Ada" {
+		t.Errorf("unexpected user %+v", u)
+	}
+
+	if key, ok := Defaults[Post]()["AuthorID"]; !ok || key != 0 {
+		t.Errorf("expected a zero AuthorID for Edges.Author, got %v", key)
+	}
+	if isEdgeKey(reflect.TypeOf(Post{}), "ID") || isEdgeKey(reflect.TypeOf(User{}), "PostsID") {
+		t.Error("expected only keys of unique edges to be edge keys")
+	}
+}
@@ -0,0 +1,110 @@
+// Package integration tests entadapter against code generated by ent for the
+// schema in ent/schema and an in-memory SQLite database. It is a separate
+// module, so that autofill itself does not depend on ent.
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/m1a9s9a4/autofill"
+	"github.com/m1a9s9a4/autofill/entadapter"
+	"github.com/m1a9s9a4/autofill/entadapter/integration/ent"
+	"github.com/m1a9s9a4/autofill/entadapter/integration/ent/user"
+)
+
+func openClient(t *testing.T) *ent.Client {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+t.Name()+"?mode=memory&cache=shared&_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func userFactory(client *ent.Client) *autofill.Factory[ent.User] {
+	return autofill.NewFactory[ent.User](autofill.New().WithSeed(1)).
+		WithPersister(entadapter.New(client)).
+		WithDefaults(entadapter.Defaults[ent.User](autofill.Override{
+			"Email": autofill.Seq("user%d@example.com"),
+			"Role":  user.RoleAdmin,
+		}))
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+
+	users, err := userFactory(client).CreateN(ctx, 3)
+	if err != nil {
+		t.Fatalf("CreateN failed: %v", err)
+	}
+
+	for _, u := range users {
+		stored, err := client.User.Get(ctx, u.ID)
+		if err != nil {
+			t.Fatalf("failed to load user %d: %v", u.ID, err)
+		}
+		if stored.Name != u.Name || stored.Email != u.Email || stored.Role != user.RoleAdmin {
+			t.Errorf("expected the stored user to match %+v, got %+v", u, stored)
+		}
+		if u.Nickname == nil || stored.Nickname == nil || *stored.Nickname != *u.Nickname {
+			t.Errorf("expected nickname %v to be stored, got %v", u.Nickname, stored.Nickname)
+		}
+	}
+}
+
+func TestCreate_Edges(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+	users := userFactory(client)
+	posts := autofill.NewFactory[ent.Post](nil).
+		WithPersister(entadapter.New(client)).
+		WithDefaults(entadapter.Defaults[ent.Post]())
+
+	author := users.MustBuild()
+	post, err := posts.Create(ctx, autofill.Override{"Edges.Author": author})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if author.ID == 0 || post.AuthorID != author.ID {
+		t.Errorf("expected the author to be created with the post, got %+v and %+v", author, post)
+	}
+
+	u, err := users.Create(ctx, autofill.Override{"Edges.Posts": []*ent.Post{posts.MustBuild(), posts.MustBuild()}})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if n := client.User.QueryPosts(u).CountX(ctx); n != 2 {
+		t.Errorf("expected 2 posts of the user, got %d", n)
+	}
+}
+
+func TestCreate_CyclicEdges(t *testing.T) {
+	ctx := context.Background()
+	client := openClient(t)
+
+	post := &ent.Post{Title: "Hello"}
+	author := userFactory(client).MustBuild(autofill.Override{"Edges.Posts": []*ent.Post{post}})
+	post.Edges.Author = author
+	if err := entadapter.New(client).Persist(ctx, post); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+
+	stored := client.Post.Query().WithAuthor().OnlyX(ctx)
+	if stored.Edges.Author == nil || stored.Edges.Author.ID != author.ID {
+		t.Errorf("expected the post of author %d, got %+v", author.ID, stored)
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Errorf("expected the author to be created once, got %d users", n)
+	}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 type TestUser struct {
-	ID          int64  `autofill:"seq"`
+	ID          int64 `autofill:"seq"`
 	Email       string
 	WorkspaceID int64
 	TeamID      string
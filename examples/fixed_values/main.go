@@ -22,10 +22,10 @@ func main() {
 	users := make([]User, 5)
 	err := autofill.FillSlice(&users, autofill.Override{
 		"Email":       autofill.Seq("user%d@example.com"), // 連番
-		"Age":         autofill.SeqInt(25),                 // 連番
-		"WorkspaceID": int64(12345),                        // 固定値
-		"TeamID":      "engineering",                       // 固定値
-		"Role":        "developer",                         // 固定値
+		"Age":         autofill.SeqInt(25),                // 連番
+		"WorkspaceID": int64(12345),                       // 固定値
+		"TeamID":      "engineering",                      // 固定値
+		"Role":        "developer",                        // 固定値
 	})
 
 	if err != nil {
@@ -40,19 +40,19 @@ func main() {
 	fmt.Println("\n=== Mixed: Fixed and Sequential ===")
 
 	type Task struct {
-		ID          int64  `autofill:"seq"`
-		Title       string
-		ProjectID   int64  // 全タスクが同じプロジェクトに属する
-		Priority    int    // 優先度は連番
-		Status      string // 全タスク同じステータス
+		ID        int64 `autofill:"seq"`
+		Title     string
+		ProjectID int64  // 全タスクが同じプロジェクトに属する
+		Priority  int    // 優先度は連番
+		Status    string // 全タスク同じステータス
 	}
 
 	tasks := make([]Task, 3)
 	err = autofill.FillSlice(&tasks, autofill.Override{
 		"Title":     autofill.Seq("Task #%d"),
-		"ProjectID": int64(999),      // 固定: 全てプロジェクト999
+		"ProjectID": int64(999),         // 固定: 全てプロジェクト999
 		"Priority":  autofill.SeqInt(1), // 連番: 1, 2, 3
-		"Status":    "pending",       // 固定: 全て"pending"
+		"Status":    "pending",          // 固定: 全て"pending"
 	})
 
 	if err != nil {
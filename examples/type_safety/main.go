@@ -21,8 +21,8 @@ func main() {
 	var user1 User
 	err := autofill.Fill(&user1, autofill.Override{
 		"ID":    "custom-uuid-12345", // string -> string: OK
-		"Age":   30,                   // int -> int: OK
-		"Score": 95.5,                 // float64 -> float64: OK
+		"Age":   30,                  // int -> int: OK
+		"Score": 95.5,                // float64 -> float64: OK
 	})
 	if err != nil {
 		fmt.Printf("   ❌ Error: %v\n", err)
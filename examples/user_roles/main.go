@@ -107,4 +107,36 @@ func main() {
 	for i, user := range workspaceBUsers {
 		fmt.Printf("  %d: ID=%d WorkspaceID=%d\n", i+1, user.ID, user.WorkspaceID)
 	}
+
+	fmt.Println("\n=== Traits Example ===")
+
+	// 1つのフィラーに名前付きのトレイトを登録する
+	filler := autofill.New().
+		WithSeed(12345).
+		WithDefaults(autofill.Override{
+			"Role":        "member",
+			"Permissions": "read",
+			"WorkspaceID": int64(1000),
+		}).
+		WithTrait("admin", autofill.Override{
+			"Role":        "admin",
+			"Permissions": "all",
+		}).
+		WithTrait("workspaceB", autofill.Override{
+			"WorkspaceID": int64(200),
+		})
+
+	// トレイトを組み合わせて使う (デフォルト < トレイト < オーバーライド)
+	mixed := make([]User, 3)
+	filler.Fill(&mixed[0])
+	filler.Fill(&mixed[1], autofill.Traits("admin"))
+	filler.Fill(&mixed[2], autofill.Traits("admin", "workspaceB"), autofill.Override{
+		"Permissions": "billing",
+	})
+
+	fmt.Println("🧩 Traits:")
+	for i, user := range mixed {
+		fmt.Printf("  %d: Role=%s Permissions=%s WorkspaceID=%d\n",
+			i+1, user.Role, user.Permissions, user.WorkspaceID)
+	}
 }
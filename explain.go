@@ -0,0 +1,285 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Origin describes where a generated value came from.
+type Origin int
+
+const (
+	// OriginType means the value was generated from the field type.
+	OriginType Origin = iota
+	// OriginDefault means the value was set by WithDefaults.
+	OriginDefault
+	// OriginOverride means the value was set by an override passed to Fill.
+	OriginOverride
+	// OriginTrait means the value was set by a trait.
+	OriginTrait
+	// OriginTypeOverride means the value was set by OfType.
+	OriginTypeOverride
+	// OriginRule means the value was generated by a rule, e.g. `autofill:"rule=email"`.
+	OriginRule
+	// OriginTag means the value was generated by a built-in tag, e.g. `autofill:"uuid"`.
+	OriginTag
+	// OriginMinMax means the value was generated from a min/max tag.
+	OriginMinMax
+	// OriginOneOf means the value was picked from a oneof tag.
+	OriginOneOf
+	// OriginEdgeCase means the value is a boundary value enabled by WithEdgeCases.
+	OriginEdgeCase
+	// OriginSkipped means the field was skipped with `autofill:"-"`.
+	OriginSkipped
+)
+
+var originNames = map[Origin]string{
+	OriginType:         "type",
+	OriginDefault:      "default",
+	OriginOverride:     "override",
+	OriginTrait:        "trait",
+	OriginTypeOverride: "type override",
+	OriginRule:         "rule",
+	OriginTag:          "tag",
+	OriginMinMax:       "min/max",
+	OriginOneOf:        "oneof",
+	OriginEdgeCase:     "edge case",
+	OriginSkipped:      "skipped",
+}
+
+func (o Origin) String() string {
+	if name, ok := originNames[o]; ok {
+		return name
+	}
+	return fmt.Sprintf("Origin(%d)", int(o))
+}
+
+// FieldTrace describes how the value at one path was produced.
+type FieldTrace struct {
+	// Path is the path of the value from the root struct, e.g. "Address.City".
+	Path string
+	// Origin is where the value came from.
+	Origin Origin
+	// Rule is the rule or tag name for OriginRule and OriginTag, the trait name
+	// for OriginTrait, the type name for OriginTypeOverride and OriginType,
+	// and "sequence" or "func" for overrides that are not direct values.
+	Rule string
+	// Params are the parameters of the struct tag, e.g. {"min": "18", "max": "65"}.
+	Params map[string]string
+	// Index is the index used to generate the value.
+	Index int
+	// Value is the final value, dereferenced if it is a non-nil pointer.
+	Value interface{}
+}
+
+// Trace is a report of how each value of a filled struct was produced.
+// It is returned by Explain.
+type Trace struct {
+	// Fields are the traces of the filled values, in the order they were filled.
+	Fields []FieldTrace
+
+	paths  map[string]int
+	layers []overrideLayer
+}
+
+// Explain fills v like Fill and returns a Trace of how each value was produced:
+// from a default, an override, a trait, a type override, a tag rule, a min/max
+// or oneof tag, an edge case or the field type.
+//
+// Example:
+//
+//	trace, err := autofill.New().Explain(&user, autofill.Override{"Name": "John"})
+//	fmt.Println(trace) // prints a table
+//	if f, ok := trace.Field("Email"); ok && f.Origin != autofill.OriginRule {
+//	    t.Errorf("Email was not generated by a rule: %+v", f)
+//	}
+func (a *Autofill) Explain(v interface{}, overrides ...Override) (*Trace, error) {
+	trace := &Trace{paths: make(map[string]int)}
+	if err := a.fill(v, 0, nil, trace, overrides); err != nil {
+		return nil, err
+	}
+
+	root := reflect.ValueOf(v).Elem()
+	for i := range trace.Fields {
+		if val, ok := valueAt(root, trace.Fields[i].Path); ok {
+			trace.Fields[i].Value = val
+		}
+	}
+	return trace, nil
+}
+
+// Explain is a convenience function that creates a new Autofill instance,
+// fills the given struct and returns a Trace of how each value was produced.
+func Explain(v interface{}, overrides ...Override) (*Trace, error) {
+	return New().Explain(v, overrides...)
+}
+
+// Field returns the trace of the value at path, e.g. "Address.City" or "Items[0].Name".
+func (t *Trace) Field(path string) (FieldTrace, bool) {
+	i, ok := t.paths[path]
+	if !ok {
+		return FieldTrace{}, false
+	}
+	return t.Fields[i], true
+}
+
+// String formats the trace as a table.
+func (t *Trace) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tSOURCE\tRULE\tPARAMS\tINDEX\tVALUE")
+	for _, f := range t.Fields {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", f.Path, f.Origin, f.Rule, formatParams(f.Params), f.Index, formatTraceValue(f.Value))
+	}
+	w.Flush()
+	return b.String()
+}
+
+// add adds f to the trace, replacing an earlier trace for the same path.
+func (t *Trace) add(f FieldTrace) {
+	if i, ok := t.paths[f.Path]; ok {
+		t.Fields[i] = f
+		return
+	}
+	t.paths[f.Path] = len(t.Fields)
+	t.Fields = append(t.Fields, f)
+}
+
+// layerFor returns the highest-precedence override layer with a key
+// addressing path, or one of its parents.
+func (t *Trace) layerFor(path string) (overrideLayer, bool) {
+	segments := pathSegments(path)
+	for _, exact := range []bool{true, false} {
+		for i := len(t.layers) - 1; i >= 0; i-- {
+			for key := range t.layers[i].override {
+				if !strings.HasPrefix(key, "$") && matchSegments(pathSegments(key), segments, exact) {
+					return t.layers[i], true
+				}
+			}
+		}
+	}
+	return overrideLayer{}, false
+}
+
+// record records that the value at the path of c was produced from origin.
+func (c *context) record(origin Origin, rule string, params map[string]string) {
+	if c.trace == nil {
+		return
+	}
+	c.trace.add(FieldTrace{Path: c.Path(), Origin: origin, Rule: rule, Params: params, Index: c.Index()})
+}
+
+// recordOverride records that the value at the path of c was set from the
+// override value, attributing it to the default, trait or override it came from.
+func (c *context) recordOverride(value interface{}) {
+	if c.trace == nil {
+		return
+	}
+
+	origin, rule := OriginOverride, ""
+	if layer, ok := c.trace.layerFor(c.Path()); ok {
+		origin, rule = layer.origin, layer.trait
+	}
+	if rule == "" {
+		switch value.(type) {
+		case SequenceFunc:
+			rule = "sequence"
+		case OverrideFunc:
+			rule = "func"
+		}
+	}
+	c.record(origin, rule, nil)
+}
+
+// pathSegments splits an override key or path into its segments,
+// e.g. "Items[0].Name" into "Items", "0" and "Name".
+func pathSegments(path string) []string {
+	var segments []string
+	for path != "" {
+		var head string
+		head, path = splitPath(path)
+		segments = append(segments, head)
+	}
+	return segments
+}
+
+// matchSegments reports whether the key segments address the path segments,
+// or one of their parents if exact is false. "*" matches any segment.
+func matchSegments(key, path []string, exact bool) bool {
+	if len(key) > len(path) || (exact && len(key) != len(path)) {
+		return false
+	}
+	for i, seg := range key {
+		if seg != path[i] && seg != "*" {
+			return false
+		}
+	}
+	return true
+}
+
+// valueAt returns the value at path in v, following pointers.
+// A non-nil pointer at path is dereferenced.
+func valueAt(v reflect.Value, path string) (interface{}, bool) {
+	for _, seg := range pathSegments(path) {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			v = v.FieldByName(seg)
+		case reflect.Slice, reflect.Array:
+			var i int
+			if _, err := fmt.Sscanf(seg, "%d", &i); err != nil || i < 0 || i >= v.Len() {
+				return nil, false
+			}
+			v = v.Index(i)
+		case reflect.Map:
+			key, err := parseMapKey(seg, v.Type().Key())
+			if err != nil {
+				return nil, false
+			}
+			v = v.MapIndex(key)
+		default:
+			return nil, false
+		}
+		if !v.IsValid() || !v.CanInterface() {
+			return nil, false
+		}
+	}
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v.Interface(), true
+}
+
+// formatParams formats tag parameters as sorted key=value pairs.
+func formatParams(params map[string]string) string {
+	pairs := make([]string, 0, len(params))
+	for k, v := range params {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+// formatTraceValue formats a value for the trace table, quoting strings
+// and shortening long values.
+func formatTraceValue(value interface{}) string {
+	var s string
+	if str, ok := value.(string); ok {
+		s = fmt.Sprintf("%q", str)
+	} else {
+		s = fmt.Sprintf("%v", value)
+	}
+	if r := []rune(s); len(r) > 40 {
+		s = string(r[:37]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,169 @@
+package autofill
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type explainAddress struct {
+	City    string
+	Country string
+}
+
+type explainUser struct {
+	ID        int64  `autofill:"seq"`
+	Email     string `autofill:"rule=email"`
+	Age       int    `autofill:"min=18,max=65"`
+	Status    string `autofill:"oneof=active|inactive"`
+	Role      string
+	Team      string
+	Name      string
+	Internal  string `autofill:"-"`
+	Address   explainAddress
+	Tags      []string
+	CreatedAt time.Time
+	Nickname  *string
+}
+
+func TestExplain(t *testing.T) {
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	af := New().
+		WithDefaults(Override{"Role": "member", "Team": "core"}).
+		WithTrait("japan", Override{"Address.Country": "Japan", "Team": "jp"})
+
+	var user explainUser
+	trace, err := af.Explain(&user, Traits("japan"), OfType[time.Time](ref), Override{
+		"Name":    "John",
+		"Tags[*]": Seq("tag%d"),
+	})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		origin Origin
+		rule   string
+		params map[string]string
+		value  interface{}
+	}{
+		{"ID", OriginTag, "seq", nil, int64(0)},
+		{"Email", OriginRule, "email", map[string]string{}, user.Email},
+		{"Age", OriginMinMax, "", map[string]string{"min": "18", "max": "65"}, user.Age},
+		{"Status", OriginOneOf, "", map[string]string{"oneof": "active|inactive"}, user.Status},
+		{"Role", OriginDefault, "", nil, "member"},
+		{"Team", OriginTrait, "japan", nil, "jp"},
+		{"Name", OriginOverride, "", nil, "John"},
+		{"Internal", OriginSkipped, "", nil, ""},
+		{"Address.City", OriginType, "string", nil, user.Address.City},
+		{"Address.Country", OriginTrait, "japan", nil, "Japan"},
+		{"Tags[1]", OriginOverride, "sequence", nil, "tag1"},
+		{"CreatedAt", OriginTypeOverride, "time.Time", nil, ref},
+		{"Nickname", OriginType, "string", nil, *user.Nickname},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			f, ok := trace.Field(tt.path)
+			if !ok {
+				t.Fatalf("expected trace for %s", tt.path)
+			}
+			if f.Origin != tt.origin {
+				t.Errorf("expected origin %s, got %s", tt.origin, f.Origin)
+			}
+			if f.Rule != tt.rule {
+				t.Errorf("expected rule %q, got %q", tt.rule, f.Rule)
+			}
+			if len(f.Params) != len(tt.params) {
+				t.Errorf("expected params %v, got %v", tt.params, f.Params)
+			}
+			for k, v := range tt.params {
+				if f.Params[k] != v {
+					t.Errorf("expected param %s=%s, got %v", k, v, f.Params)
+				}
+			}
+			if f.Value != tt.value {
+				t.Errorf("expected value %v, got %v", tt.value, f.Value)
+			}
+		})
+	}
+
+	if f, _ := trace.Field("Tags[2]"); f.Index != 2 {
+		t.Errorf("expected index 2 for Tags[2], got %d", f.Index)
+	}
+	if _, ok := trace.Field("Missing"); ok {
+		t.Error("expected no trace for unknown path")
+	}
+}
+
+func TestExplain_EdgeCase(t *testing.T) {
+	type Box struct {
+		Label string
+	}
+
+	var box Box
+	trace, err := New().WithSeed(1).WithEdgeCases(1).Explain(&box)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if f, _ := trace.Field("Label"); f.Origin != OriginEdgeCase {
+		t.Errorf("expected edge case origin, got %s", f.Origin)
+	}
+}
+
+func TestExplain_MapKeysNotTraced(t *testing.T) {
+	type Config struct {
+		Limits map[string]int
+	}
+
+	var cfg Config
+	trace, err := New().Explain(&cfg)
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+	if len(trace.Fields) != len(cfg.Limits) {
+		t.Errorf("expected one trace per map value, got %v", trace.Fields)
+	}
+	for key, val := range cfg.Limits {
+		if f, ok := trace.Field("Limits[" + key + "]"); !ok || f.Value != val {
+			t.Errorf("unexpected trace %+v for key %s", f, key)
+		}
+	}
+}
+
+func TestTrace_String(t *testing.T) {
+	var user explainUser
+	trace, err := New().Explain(&user, Override{"Name": "John"})
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(trace.String()), "\n")
+	if !strings.HasPrefix(lines[0], "PATH") || !strings.Contains(lines[0], "SOURCE") || !strings.Contains(lines[0], "VALUE") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if len(lines) != len(trace.Fields)+1 {
+		t.Errorf("expected %d rows, got %d", len(trace.Fields)+1, len(lines))
+	}
+
+	found := false
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "Age" {
+			found = true
+			if !strings.Contains(line, "min/max") || !strings.Contains(line, "max=65 min=18") {
+				t.Errorf("unexpected row %q", line)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected a row for Age")
+	}
+}
+
+func TestExplain_InvalidInput(t *testing.T) {
+	if _, err := Explain(explainUser{}); err == nil {
+		t.Error("expected error for non-pointer input")
+	}
+}
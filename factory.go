@@ -0,0 +1,167 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Factory builds values of type T with its own defaults, traits, sequence
+// counter and hooks. Create one with NewFactory.
+//
+// Every value built by a factory gets the next index of its sequence counter,
+// so `autofill:"seq"` tags and sequence overrides such as Seq keep counting
+// across Build and BuildN calls.
+//
+// Once configured, a Factory is safe for concurrent use by multiple goroutines.
+type Factory[T any] struct {
+	filler       *Autofill
+	seq          atomic.Int64
+	afterBuild   []func(*T)
+	beforeCreate []func(*T)
+	belongsTo    []belongsTo
+	hasMany      []hasMany
+}
+
+// NewFactory creates a Factory for T that fills values with a copy of the
+// configuration of a. If a is nil, New() is used.
+//
+// Example:
+//
+//	users := autofill.NewFactory[User](autofill.New().WithSeed(42)).
+//	    WithDefaults(autofill.Override{"Role": "member"}).
+//	    WithTrait("admin", autofill.Override{"Role": "admin"})
+//
+//	admin := users.MustBuild(autofill.Traits("admin"))
+func NewFactory[T any](a *Autofill) *Factory[T] {
+	if a == nil {
+		a = New()
+	}
+	filler := *a
+	return &Factory[T]{filler: &filler}
+}
+
+// WithDefaults sets the default overrides of the factory, replacing the
+// defaults copied from the Autofill instance.
+func (f *Factory[T]) WithDefaults(defaults Override) *Factory[T] {
+	f.filler.WithDefaults(defaults)
+	return f
+}
+
+// WithTrait registers a named set of overrides on the factory.
+// See Autofill.WithTrait.
+func (f *Factory[T]) WithTrait(name string, override Override) *Factory[T] {
+	f.filler.WithTrait(name, override)
+	return f
+}
+
+// AfterBuild registers a hook that is called with every value the factory builds,
+// after it has been filled. Hooks are called in the order they were registered.
+func (f *Factory[T]) AfterBuild(fn func(*T)) *Factory[T] {
+	f.afterBuild = append(f.afterBuild, fn)
+	return f
+}
+
+// BeforeCreate registers a hook that is called with every value the factory
+// creates, after it has been built and before it is persisted.
+// Hooks are called in the order they were registered.
+func (f *Factory[T]) BeforeCreate(fn func(*T)) *Factory[T] {
+	f.beforeCreate = append(f.beforeCreate, fn)
+	return f
+}
+
+// Build fills a new T with the next index of the sequence counter and returns it.
+// Overrides are applied as in Fill: factory defaults, then traits, then the
+// other overrides.
+func (f *Factory[T]) Build(overrides ...Override) (*T, error) {
+	index := int(f.seq.Add(1) - 1)
+	v := new(T)
+	if err := f.build(v, index, overrides, nil); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// MustBuild is like Build but panics if the value cannot be built.
+// It simplifies building fixtures in tests.
+func (f *Factory[T]) MustBuild(overrides ...Override) *T {
+	v, err := f.Build(overrides...)
+	if err != nil {
+		panic(fmt.Sprintf("autofill: failed to build %T: %v", v, err))
+	}
+	return v
+}
+
+// BuildN builds n values with consecutive indexes of the sequence counter.
+func (f *Factory[T]) BuildN(n int, overrides ...Override) ([]T, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("BuildN requires a non-negative count, got %d", n)
+	}
+
+	start := int(f.seq.Add(int64(n))) - n
+	items := make([]T, n)
+	for i := range items {
+		if err := f.build(&items[i], start+i, overrides, nil); err != nil {
+			return nil, fmt.Errorf("failed to build element at index %d: %w", i, err)
+		}
+	}
+	return items, nil
+}
+
+// ResetSequence resets the sequence counter, so that the next value is built
+// with index 0 again.
+func (f *Factory[T]) ResetSequence() {
+	f.seq.Store(0)
+}
+
+// build fills v with index, builds its associations and calls the AfterBuild hooks.
+// If s is not nil, parents are created instead and children are left to the caller.
+func (f *Factory[T]) build(v *T, index int, overrides []Override, s *session) error {
+	if err := f.filler.FillWithIndex(v, index, overrides...); err != nil {
+		return err
+	}
+	if err := f.associateParents(reflect.ValueOf(v).Elem(), overrides, s); err != nil {
+		return err
+	}
+	if s == nil {
+		if err := f.associateChildren(reflect.ValueOf(v).Elem(), index, overrides, nil); err != nil {
+			return err
+		}
+	}
+	for _, fn := range f.afterBuild {
+		fn(v)
+	}
+	return nil
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[reflect.Type]interface{})
+)
+
+// RegisterFactory registers f as the factory for T, replacing any factory
+// registered before. Registered factories can be looked up with FactoryFor,
+// so packages can share them without importing each other's test helpers.
+//
+//	func init() {
+//	    autofill.RegisterFactory(autofill.NewFactory[User](nil).
+//	        WithDefaults(autofill.Override{"Role": "member"}))
+//	}
+func RegisterFactory[T any](f *Factory[T]) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[reflect.TypeOf((*T)(nil)).Elem()] = f
+}
+
+// FactoryFor returns the factory registered for T.
+// It returns false if no factory has been registered.
+func FactoryFor[T any]() (*Factory[T], bool) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	f, ok := factories[reflect.TypeOf((*T)(nil)).Elem()]
+	if !ok {
+		return nil, false
+	}
+	return f.(*Factory[T]), true
+}
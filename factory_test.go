@@ -0,0 +1,176 @@
+package autofill
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type factoryUser struct {
+	ID     int64  `autofill:"seq"`
+	Email  string `autofill:"email"`
+	Name   string
+	Role   string
+	Status string
+}
+
+func newUserFactory() *Factory[factoryUser] {
+	return NewFactory[factoryUser](New().WithSeed(1)).
+		WithDefaults(Override{"Role": "member", "Status": "active"}).
+		WithTrait("admin", Override{"Role": "admin"}).
+		WithTrait("suspended", Override{"Status": "suspended"})
+}
+
+func TestFactory_Build(t *testing.T) {
+	users := newUserFactory()
+
+	u, err := users.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if u.Role != "member" || u.Status != "active" || u.Name == "" {
+		t.Errorf("unexpected user %+v", u)
+	}
+
+	admin, err := users.Build(Traits("admin", "suspended"), Override{"Name": "Root"})
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if admin.Role != "admin" || admin.Status != "suspended" || admin.Name != "Root" {
+		t.Errorf("unexpected admin %+v", admin)
+	}
+}
+
+func TestFactory_Sequence(t *testing.T) {
+	users := newUserFactory()
+
+	first := users.MustBuild(Override{"Email": Seq("user%d@example.com")})
+	rest, err := users.BuildN(3, Override{"Email": Seq("user%d@example.com")})
+	if err != nil {
+		t.Fatalf("BuildN failed: %v", err)
+	}
+
+	all := append([]factoryUser{*first}, rest...)
+	for i, u := range all {
+		if u.ID != int64(i) {
+			t.Errorf("user %d: expected ID %d, got %d", i, i, u.ID)
+		}
+		if want := fmt.Sprintf("user%d@example.com", i); u.Email != want {
+			t.Errorf("user %d: expected Email %s, got %s", i, want, u.Email)
+		}
+	}
+
+	users.ResetSequence()
+	if u := users.MustBuild(); u.ID != 0 {
+		t.Errorf("expected ID 0 after reset, got %d", u.ID)
+	}
+}
+
+func TestFactory_ConcurrentBuild(t *testing.T) {
+	users := newUserFactory()
+
+	var mu sync.Mutex
+	seen := make(map[int64]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u := users.MustBuild()
+			mu.Lock()
+			seen[u.ID] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != 50 {
+		t.Errorf("expected 50 distinct IDs, got %d", len(seen))
+	}
+}
+
+func TestFactory_AfterBuild(t *testing.T) {
+	var calls []string
+	users := newUserFactory().
+		AfterBuild(func(u *factoryUser) {
+			u.Name = strings.ToUpper(u.Name)
+			calls = append(calls, "first")
+		}).
+		AfterBuild(func(u *factoryUser) {
+			calls = append(calls, "second:"+u.Name)
+		})
+
+	u := users.MustBuild(Override{"Name": "john"})
+	if u.Name != "JOHN" {
+		t.Errorf("expected hook to modify Name, got %s", u.Name)
+	}
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second:JOHN" {
+		t.Errorf("expected hooks in order, got %v", calls)
+	}
+
+	calls = nil
+	if _, err := users.BuildN(2); err != nil {
+		t.Fatalf("BuildN failed: %v", err)
+	}
+	if len(calls) != 4 {
+		t.Errorf("expected hooks for each element, got %v", calls)
+	}
+}
+
+func TestFactory_Errors(t *testing.T) {
+	users := newUserFactory()
+
+	if _, err := users.Build(Traits("unknown")); err == nil {
+		t.Error("expected error for unknown trait")
+	}
+	if _, err := users.BuildN(-1); err == nil {
+		t.Error("expected error for negative count")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustBuild to panic")
+		}
+	}()
+	users.MustBuild(Traits("unknown"))
+}
+
+func TestFactory_DoesNotModifyFiller(t *testing.T) {
+	base := New().WithDefaults(Override{"Role": "guest"})
+	NewFactory[factoryUser](base).
+		WithDefaults(Override{"Role": "member"}).
+		WithTrait("admin", Override{"Role": "admin"})
+
+	var u factoryUser
+	if err := base.Fill(&u); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if u.Role != "guest" {
+		t.Errorf("expected base filler defaults to be unchanged, got %s", u.Role)
+	}
+	if err := base.Fill(&u, Traits("admin")); err == nil {
+		t.Error("expected factory traits not to be registered on the base filler")
+	}
+}
+
+func TestRegisterFactory(t *testing.T) {
+	type registeredUser struct {
+		Name string
+	}
+
+	if _, ok := FactoryFor[registeredUser](); ok {
+		t.Fatal("expected no factory before registration")
+	}
+
+	f := NewFactory[registeredUser](nil).WithDefaults(Override{"Name": "registered"})
+	RegisterFactory(f)
+
+	got, ok := FactoryFor[registeredUser]()
+	if !ok || got != f {
+		t.Fatalf("expected registered factory, got %v (ok=%v)", got, ok)
+	}
+	if u := got.MustBuild(); u.Name != "registered" {
+		t.Errorf("expected Name registered, got %s", u.Name)
+	}
+}
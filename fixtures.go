@@ -0,0 +1,456 @@
+package autofill
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadOverrides reads an Override from a JSON or YAML fixture file.
+// The format is chosen by the file extension: .json, .yaml or .yml.
+// See OverridesFromJSON for how values are decoded.
+//
+// Example:
+//
+//	admins, err := autofill.LoadOverrides("testdata/admins.yaml")
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	autofill.New().WithDefaults(admins).FillSlice(&users)
+func LoadOverrides(path string) (Override, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open overrides: %w", err)
+	}
+	defer f.Close()
+
+	var override Override
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		override, err = OverridesFromJSON(f)
+	case ".yaml", ".yml":
+		override, err = OverridesFromYAML(f)
+	default:
+		return nil, fmt.Errorf("unsupported overrides file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load overrides from %s: %w", path, err)
+	}
+	return override, nil
+}
+
+// OverridesFromJSON decodes an Override from a JSON object.
+//
+// Keys are override keys, including nested paths such as "Address.City".
+// Objects become nested overrides, so unaddressed fields are still generated.
+// Other values are converted to the type of their field when the struct is
+// filled: numbers to any numeric type, strings to numbers, booleans, times
+// (RFC 3339 or "2006-01-02"), durations ("1h30m") and types implementing
+// encoding.TextUnmarshaler, arrays to slices, and null to the zero value.
+//
+// Objects with a single key starting with "$" are sequences:
+//
+//	{"$seq": "user%d@example.com"}  // like Seq
+//	{"$seq": 1000}                  // 1000, 1001, 1002, ...
+//	{"$cycle": ["admin", "editor"]} // like Cycle
+//	{"$shuffle": ["red", "green"]}  // like Shuffle
+//	{"$random": [1, 100]}           // like RandomInt
+//
+// Example:
+//
+//	{
+//	    "Role":     "admin",
+//	    "TenantID": 1000,
+//	    "Email":    {"$seq": "admin%d@example.com"},
+//	    "Address":  {"Country": "Japan"}
+//	}
+func OverridesFromJSON(r io.Reader) (Override, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON overrides: %w", err)
+	}
+	return toOverride(raw)
+}
+
+// OverridesFromYAML decodes an Override from a YAML mapping.
+// Values are decoded as described for OverridesFromJSON.
+//
+// Example:
+//
+//	Role: admin
+//	TenantID: 1000
+//	Email: {$seq: "admin%d@example.com"}
+//	Address:
+//	  Country: Japan
+func OverridesFromYAML(r io.Reader) (Override, error) {
+	var raw map[string]interface{}
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode YAML overrides: %w", err)
+	}
+	return toOverride(raw)
+}
+
+// fixtureValue is a decoded fixture value that is converted to the type of
+// its field when it is set.
+type fixtureValue struct {
+	raw interface{}
+}
+
+// toOverride converts a decoded object to an Override.
+func toOverride(raw map[string]interface{}) (Override, error) {
+	override := make(Override, len(raw))
+	for key, value := range raw {
+		converted, err := toOverrideValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid override %q: %w", key, err)
+		}
+		override[key] = converted
+	}
+	return override, nil
+}
+
+// toOverrideValue converts a decoded value to an override value:
+// a sequence, a nested Override or a fixtureValue.
+func toOverrideValue(value interface{}) (interface{}, error) {
+	obj, ok := toObject(value)
+	if !ok {
+		return fixtureValue{raw: value}, nil
+	}
+
+	for key, arg := range obj {
+		if strings.HasPrefix(key, "$") {
+			if len(obj) != 1 {
+				return nil, fmt.Errorf("sequence %q must be the only key of its object", key)
+			}
+			return toSequence(key, arg)
+		}
+	}
+	return toOverride(obj)
+}
+
+// toSequence converts a sequence object such as {"$seq": "user%d"} to an override value.
+func toSequence(name string, arg interface{}) (interface{}, error) {
+	switch name {
+	case "$seq":
+		if format, ok := arg.(string); ok {
+			return Seq(format), nil
+		}
+		start, err := toInt64(arg)
+		if err != nil {
+			return nil, fmt.Errorf("$seq requires a format string or an integer start: %w", err)
+		}
+		return SequenceFunc(func(index int) interface{} {
+			return fixtureValue{raw: start + int64(index)}
+		}), nil
+	case "$cycle", "$shuffle":
+		items, ok := arg.([]interface{})
+		if !ok || len(items) == 0 {
+			return nil, fmt.Errorf("%s requires a non-empty list", name)
+		}
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			values[i] = fixtureValue{raw: item}
+		}
+		if name == "$cycle" {
+			return Cycle(values...), nil
+		}
+		return Shuffle(values...), nil
+	case "$random":
+		bounds, ok := arg.([]interface{})
+		if !ok || len(bounds) != 2 {
+			return nil, fmt.Errorf("$random requires a list of min and max")
+		}
+		min, err := toInt64(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid $random min: %w", err)
+		}
+		max, err := toInt64(bounds[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid $random max: %w", err)
+		}
+		if max < min {
+			return nil, fmt.Errorf("$random max %d is less than min %d", max, min)
+		}
+		return RandomInt(int(min), int(max)), nil
+	}
+	return nil, fmt.Errorf("unknown sequence %q", name)
+}
+
+// toObject returns value as an object if it is a decoded JSON object or YAML mapping.
+func toObject(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			obj[fmt.Sprint(key)] = val
+		}
+		return obj, true
+	}
+	return nil, false
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// set converts the fixture value to the type of dst and sets it.
+func (f fixtureValue) set(dst reflect.Value) error {
+	val, err := convertFixture(f.raw, dst.Type())
+	if err != nil {
+		return err
+	}
+	dst.Set(val)
+	return nil
+}
+
+// convertFixture converts a decoded value to a value of type typ.
+func convertFixture(raw interface{}, typ reflect.Type) (reflect.Value, error) {
+	if raw == nil {
+		return reflect.Zero(typ), nil
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		elem, err := convertFixture(raw, typ.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	}
+
+	if rv := reflect.ValueOf(raw); rv.Type().AssignableTo(typ) {
+		return rv, nil
+	}
+	if s, ok := raw.(string); ok {
+		return convertFixtureString(s, typ)
+	}
+	if typ.Kind() == reflect.String {
+		if s, ok := fixtureText(raw); ok {
+			return convertFixtureString(s, typ)
+		}
+	}
+
+	val := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt64(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if val.OverflowInt(n) {
+			return reflect.Value{}, fmt.Errorf("value %d overflows %s", n, typ)
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt64(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if n < 0 || val.OverflowUint(uint64(n)) {
+			return reflect.Value{}, fmt.Errorf("value %d overflows %s", n, typ)
+		}
+		val.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat64(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetFloat(f)
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v (%T) to %s", raw, raw, typ)
+		}
+		val.SetBool(b)
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v (%T) to %s", raw, raw, typ)
+		}
+		if typ.Kind() == reflect.Slice {
+			val = reflect.MakeSlice(typ, len(items), len(items))
+		} else if len(items) > typ.Len() {
+			return reflect.Value{}, fmt.Errorf("%d values do not fit in %s", len(items), typ)
+		}
+		for i, item := range items {
+			elem, err := convertFixture(item, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("[%d]: %w", i, err)
+			}
+			val.Index(i).Set(elem)
+		}
+	case reflect.Map:
+		obj, ok := toObject(raw)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v (%T) to %s", raw, raw, typ)
+		}
+		val = reflect.MakeMapWithSize(typ, len(obj))
+		for key, item := range obj {
+			keyVal, err := parseMapKey(key, typ.Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			elem, err := convertFixture(item, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("[%s]: %w", key, err)
+			}
+			val.SetMapIndex(keyVal, elem)
+		}
+	case reflect.Struct:
+		obj, ok := toObject(raw)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v (%T) to %s", raw, raw, typ)
+		}
+		for name, item := range obj {
+			field, ok := typ.FieldByName(name)
+			if !ok || !field.IsExported() {
+				return reflect.Value{}, fmt.Errorf("%s has no exported field %q", typ, name)
+			}
+			elem, err := convertFixture(item, field.Type)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			val.FieldByIndex(field.Index).Set(elem)
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot convert %v (%T) to %s", raw, raw, typ)
+	}
+	return val, nil
+}
+
+// convertFixtureString converts a decoded string to a value of type typ.
+func convertFixtureString(s string, typ reflect.Type) (reflect.Value, error) {
+	val := reflect.New(typ).Elem()
+
+	if typ == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetInt(int64(d))
+		return val, nil
+	}
+	if typ == reflect.TypeOf(time.Time{}) {
+		if t, err := time.Parse("2006-01-02", s); err == nil {
+			val.Set(reflect.ValueOf(t))
+			return val, nil
+		}
+	}
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		if err := val.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return reflect.Value{}, err
+		}
+		return val, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		val.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, typ.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		val.SetBool(b)
+	case reflect.Slice:
+		if typ.Elem().Kind() != reflect.Uint8 {
+			return reflect.Value{}, fmt.Errorf("cannot convert string %q to %s", s, typ)
+		}
+		val.SetBytes([]byte(s))
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot convert string %q to %s", s, typ)
+	}
+	return val, nil
+}
+
+// fixtureText formats a decoded number or bool for a string field, e.g. a
+// ZIP code or phone number written without quotes.
+func fixtureText(raw interface{}) (string, bool) {
+	switch v := raw.(type) {
+	case json.Number:
+		return v.String(), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// toInt64 converts a decoded number to an int64.
+func toInt64(raw interface{}) (int64, error) {
+	switch n := raw.(type) {
+	case json.Number:
+		return n.Int64()
+	case int:
+		return int64(n), nil
+	case int64:
+		return n, nil
+	case uint64:
+		if n > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows int64", n)
+		}
+		return int64(n), nil
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, fmt.Errorf("value %v is not an integer", n)
+		}
+		return int64(n), nil
+	}
+	return 0, fmt.Errorf("cannot convert %v (%T) to an integer", raw, raw)
+}
+
+// toFloat64 converts a decoded number to a float64.
+func toFloat64(raw interface{}) (float64, error) {
+	switch n := raw.(type) {
+	case json.Number:
+		return n.Float64()
+	case float64:
+		return n, nil
+	}
+	if i, err := toInt64(raw); err == nil {
+		return float64(i), nil
+	}
+	return 0, fmt.Errorf("cannot convert %v (%T) to a float", raw, raw)
+}
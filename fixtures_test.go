@@ -0,0 +1,244 @@
+package autofill
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type fixtureAddress struct {
+	City    string
+	Country string
+}
+
+type fixtureItem struct {
+	Name  string
+	Price int
+}
+
+type fixtureUser struct {
+	ID        int64
+	Role      string
+	TenantID  int64
+	Email     string
+	Level     uint8
+	Since     time.Time
+	Address   fixtureAddress
+	Score     float32
+	Active    bool
+	Tags      []string
+	Items     []fixtureItem
+	Limits    map[string]int
+	Timeout   time.Duration
+	DeletedAt *time.Time
+	Nickname  *string
+}
+
+func TestLoadOverrides(t *testing.T) {
+	for _, path := range []string{"testdata/admins.yaml", "testdata/admins.json"} {
+		t.Run(path, func(t *testing.T) {
+			admins, err := LoadOverrides(path)
+			if err != nil {
+				t.Fatalf("LoadOverrides failed: %v", err)
+			}
+
+			users := make([]fixtureUser, 4)
+			if err := New().WithStrict(true).WithDefaults(admins).FillSlice(&users); err != nil {
+				t.Fatalf("FillSlice failed: %v", err)
+			}
+
+			since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			for i, u := range users {
+				if u.Role != "admin" || u.TenantID != 1000 {
+					t.Errorf("user %d: expected admin in tenant 1000, got %s in %d", i, u.Role, u.TenantID)
+				}
+				if want := "admin" + string(rune('0'+i)) + "@example.com"; u.Email != want {
+					t.Errorf("user %d: expected Email %s, got %s", i, want, u.Email)
+				}
+				if want := uint8(i%3 + 1); u.Level != want {
+					t.Errorf("user %d: expected Level %d, got %d", i, want, u.Level)
+				}
+				if !u.Since.Equal(since) {
+					t.Errorf("user %d: expected Since %v, got %v", i, since, u.Since)
+				}
+				if u.Address.Country != "Japan" || u.Address.City == "" {
+					t.Errorf("user %d: expected Japan with a generated city, got %+v", i, u.Address)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadOverrides_Errors(t *testing.T) {
+	if _, err := LoadOverrides("testdata/missing.json"); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if _, err := LoadOverrides("fixtures.go"); err == nil {
+		t.Error("expected error for unsupported extension")
+	}
+}
+
+func TestOverridesFromJSON_Conversions(t *testing.T) {
+	override, err := OverridesFromJSON(strings.NewReader(`{
+		"ID": {"$seq": 1000},
+		"Score": 9.5,
+		"Active": "true",
+		"Tags": ["a", "b"],
+		"Items": [{"Name": "first", "Price": 100}, {"Name": "second", "Price": "200"}],
+		"Limits": {"daily": 10},
+		"Timeout": "1m30s",
+		"DeletedAt": null,
+		"Nickname": "johnny",
+		"Address.City": "Tokyo"
+	}`))
+	if err != nil {
+		t.Fatalf("OverridesFromJSON failed: %v", err)
+	}
+
+	users := make([]fixtureUser, 2)
+	if err := New().FillSlice(&users, override); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	u := users[1]
+	if users[0].ID != 1000 || u.ID != 1001 {
+		t.Errorf("expected IDs 1000 and 1001, got %d and %d", users[0].ID, u.ID)
+	}
+	if u.Score != 9.5 || !u.Active {
+		t.Errorf("unexpected Score %v or Active %v", u.Score, u.Active)
+	}
+	if len(u.Tags) != 2 || u.Tags[0] != "a" || u.Tags[1] != "b" {
+		t.Errorf("unexpected Tags %v", u.Tags)
+	}
+	if len(u.Items) != 2 || u.Items[0] != (fixtureItem{"first", 100}) || u.Items[1] != (fixtureItem{"second", 200}) {
+		t.Errorf("unexpected Items %+v", u.Items)
+	}
+	if u.Limits["daily"] != 10 {
+		t.Errorf("expected Limits[daily] 10, got %v", u.Limits)
+	}
+	if u.Timeout != 90*time.Second {
+		t.Errorf("expected Timeout 1m30s, got %v", u.Timeout)
+	}
+	if u.DeletedAt != nil {
+		t.Errorf("expected nil DeletedAt, got %v", u.DeletedAt)
+	}
+	if u.Nickname == nil || *u.Nickname != "johnny" {
+		t.Errorf("unexpected Nickname %v", u.Nickname)
+	}
+	if u.Address.City != "Tokyo" {
+		t.Errorf("expected Address.City Tokyo, got %s", u.Address.City)
+	}
+}
+
+func TestOverrides_NumbersIntoStrings(t *testing.T) {
+	type productCode string
+	type contact struct {
+		Zip      string
+		Phone    string
+		Code     productCode
+		Rating   string
+		Verified string
+	}
+
+	fromYAML, err := OverridesFromYAML(strings.NewReader(`
+Zip: 12345
+Phone: 819012345678
+Code: 7
+Rating: 4.5
+Verified: true
+`))
+	if err != nil {
+		t.Fatalf("OverridesFromYAML failed: %v", err)
+	}
+	fromJSON, err := OverridesFromJSON(strings.NewReader(`{
+		"Zip": 12345, "Phone": 819012345678, "Code": 7, "Rating": 4.5, "Verified": true
+	}`))
+	if err != nil {
+		t.Fatalf("OverridesFromJSON failed: %v", err)
+	}
+
+	want := contact{Zip: "12345", Phone: "819012345678", Code: "7", Rating: "4.5", Verified: "true"}
+	for name, override := range map[string]Override{"YAML": fromYAML, "JSON": fromJSON} {
+		var c contact
+		if err := Fill(&c, override); err != nil {
+			t.Fatalf("%s: Fill failed: %v", name, err)
+		}
+		if c != want {
+			t.Errorf("%s: expected %+v, got %+v", name, want, c)
+		}
+	}
+}
+
+func TestOverridesFromYAML_Sequences(t *testing.T) {
+	override, err := OverridesFromYAML(strings.NewReader(`
+Role: {$shuffle: [admin, editor, viewer]}
+TenantID: {$random: [1, 5]}
+`))
+	if err != nil {
+		t.Fatalf("OverridesFromYAML failed: %v", err)
+	}
+
+	users := make([]fixtureUser, 3)
+	if err := New().WithSeed(1).FillSlice(&users, override); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	roles := make(map[string]bool)
+	for i, u := range users {
+		roles[u.Role] = true
+		if u.TenantID < 1 || u.TenantID > 5 {
+			t.Errorf("user %d: TenantID %d out of range [1, 5]", i, u.TenantID)
+		}
+	}
+	if len(roles) != 3 {
+		t.Errorf("expected each role once, got %v", roles)
+	}
+}
+
+func TestOverridesFromJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid JSON", `{"Name": `},
+		{"not an object", `["Name"]`},
+		{"unknown sequence", `{"Name": {"$nope": 1}}`},
+		{"sequence with other keys", `{"Name": {"$seq": "a%d", "Other": 1}}`},
+		{"empty cycle", `{"Name": {"$cycle": []}}`},
+		{"invalid random", `{"Age": {"$random": [10, 1]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := OverridesFromJSON(strings.NewReader(tt.input)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestOverridesFromJSON_ConversionErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"overflow", `{"Level": 300}`},
+		{"string to bool", `{"Active": "maybe"}`},
+		{"array to string", `{"Role": [5]}`},
+		{"fraction to int", `{"TenantID": 1.5}`},
+		{"unknown struct field", `{"Items": [{"Nmae": "x"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			override, err := OverridesFromJSON(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("OverridesFromJSON failed: %v", err)
+			}
+			var u fixtureUser
+			if err := Fill(&u, override); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
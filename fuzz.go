@@ -0,0 +1,43 @@
+package autofill
+
+import "encoding/binary"
+
+// FillFromBytes is like Fill but uses data as the source of randomness.
+// Every generator draws from data in random mode, while tags, rules and
+// overrides are still respected. Once data is exhausted, it is treated as
+// padded with zeros, so any input produces a valid value.
+//
+// It lets native Go fuzzing explore structured inputs:
+//
+//	func FuzzCreateUser(f *testing.F) {
+//	    f.Fuzz(func(t *testing.T, data []byte) {
+//	        var req CreateUserRequest
+//	        if err := autofill.FillFromBytes(&req, data); err != nil {
+//	            t.Skip(err)
+//	        }
+//	        handleCreateUser(req)
+//	    })
+//	}
+func (a *Autofill) FillFromBytes(v interface{}, data []byte, overrides ...Override) error {
+	r := newRand(&bytesSource{data: data})
+	return a.fill(v, 0, r, nil, overrides)
+}
+
+// FillFromBytes is a convenience function that creates a new Autofill instance
+// and fills the given struct using data as the source of randomness.
+func FillFromBytes(v interface{}, data []byte, overrides ...Override) error {
+	return New().FillFromBytes(v, data, overrides...)
+}
+
+// bytesSource is a Source that reads its values from a byte slice.
+type bytesSource struct {
+	data []byte
+}
+
+// Uint64 returns the next 8 bytes of data as a little-endian integer.
+func (s *bytesSource) Uint64() uint64 {
+	var buf [8]byte
+	n := copy(buf[:], s.data)
+	s.data = s.data[n:]
+	return binary.LittleEndian.Uint64(buf[:])
+}
@@ -0,0 +1,97 @@
+package autofill
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fuzzRequest struct {
+	Name   string
+	Email  string `autofill:"email"`
+	Age    int    `autofill:"min=18,max=65"`
+	Role   string `autofill:"oneof=admin|member|guest"`
+	ID     string `autofill:"uuid"`
+	Score  float64
+	Active bool
+	Tags   []string
+}
+
+func TestFillFromBytes_Deterministic(t *testing.T) {
+	data := []byte("some fuzzer provided bytes that drive generation")
+
+	var req1, req2 fuzzRequest
+	if err := FillFromBytes(&req1, data); err != nil {
+		t.Fatalf("FillFromBytes failed: %v", err)
+	}
+	if err := FillFromBytes(&req2, data); err != nil {
+		t.Fatalf("FillFromBytes failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(req1, req2) {
+		t.Errorf("expected same value for same bytes, got %+v and %+v", req1, req2)
+	}
+}
+
+func TestFillFromBytes_BytesChangeData(t *testing.T) {
+	var req1, req2 fuzzRequest
+	if err := FillFromBytes(&req1, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}); err != nil {
+		t.Fatalf("FillFromBytes failed: %v", err)
+	}
+	if err := FillFromBytes(&req2, []byte{200, 100, 50, 25, 12, 6, 3, 1, 99, 98, 97, 96, 95, 94, 93, 92}); err != nil {
+		t.Fatalf("FillFromBytes failed: %v", err)
+	}
+
+	if reflect.DeepEqual(req1, req2) {
+		t.Error("expected different values for different bytes")
+	}
+}
+
+func TestFillFromBytes_IgnoresSeed(t *testing.T) {
+	data := []byte{42, 17, 99, 3, 250, 128, 64, 32, 16, 8}
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return ref }
+
+	var req1, req2 fuzzRequest
+	if err := New().WithSeed(1).WithClock(clock).FillFromBytes(&req1, data); err != nil {
+		t.Fatalf("FillFromBytes failed: %v", err)
+	}
+	if err := New().WithSeed(2).WithClock(clock).FillFromBytes(&req2, data); err != nil {
+		t.Fatalf("FillFromBytes failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(req1, req2) {
+		t.Errorf("expected values to depend only on the bytes, got %+v and %+v", req1, req2)
+	}
+}
+
+func TestFillFromBytes_Overrides(t *testing.T) {
+	var req fuzzRequest
+	if err := FillFromBytes(&req, []byte{1, 2, 3}, Override{"Role": "owner"}); err != nil {
+		t.Fatalf("FillFromBytes failed: %v", err)
+	}
+
+	if req.Role != "owner" {
+		t.Errorf("expected Role owner, got %s", req.Role)
+	}
+}
+
+func FuzzFillFromBytes(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte("hello world"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var req fuzzRequest
+		if err := FillFromBytes(&req, data); err != nil {
+			t.Fatalf("FillFromBytes failed: %v", err)
+		}
+
+		if req.Age < 18 || req.Age > 65 {
+			t.Errorf("Age %d is out of range [18, 65]", req.Age)
+		}
+		if req.Role != "admin" && req.Role != "member" && req.Role != "guest" {
+			t.Errorf("unexpected Role %s", req.Role)
+		}
+	})
+}
@@ -2,8 +2,8 @@ package autofill
 
 import (
 	"fmt"
-	"math/rand"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -13,9 +13,15 @@ func (a *Autofill) generateValue(field reflect.StructField, ctx *context) (inter
 	// Check for autofill tag
 	tag := field.Tag.Get("autofill")
 	if tag == "-" {
+		ctx.record(OriginSkipped, "", nil)
 		return nil, nil // Skip this field
 	}
 
+	// Type overrides take precedence over tags
+	if val, ok, err := a.generateTypeOverride(field.Type, ctx); ok || err != nil {
+		return val, err
+	}
+
 	// Parse tag if present
 	if tag != "" {
 		val, err := a.generateFromTag(tag, field, ctx)
@@ -42,6 +48,8 @@ func (a *Autofill) generateFromTag(tag string, field reflect.StructField, ctx *c
 	if ruleName, ok := params["rule"]; ok {
 		if a.rules != nil {
 			if rule, ok := a.rules.Get(ruleName); ok {
+				delete(params, "rule")
+				ctx.record(OriginRule, ruleName, params)
 				return rule.Generate(ctx)
 			}
 		}
@@ -51,11 +59,15 @@ func (a *Autofill) generateFromTag(tag string, field reflect.StructField, ctx *c
 	// Handle built-in tags (first part without =)
 	mainTag := strings.TrimSpace(parts[0])
 	if !strings.Contains(mainTag, "=") {
+		switch mainTag {
+		case "seq", "now", "email", "url", "uuid":
+			ctx.record(OriginTag, mainTag, nil)
+		}
 		switch mainTag {
 		case "seq":
 			return int64(ctx.Index()), nil
 		case "now":
-			return time.Now(), nil
+			return ctx.Now(), nil
 		case "email":
 			return a.generateEmail(ctx), nil
 		case "url":
@@ -67,6 +79,7 @@ func (a *Autofill) generateFromTag(tag string, field reflect.StructField, ctx *c
 		// Check if it's a direct rule name (without "rule=" prefix)
 		if a.rules != nil {
 			if rule, ok := a.rules.Get(mainTag); ok {
+				ctx.record(OriginRule, mainTag, params)
 				return rule.Generate(ctx)
 			}
 		}
@@ -79,8 +92,11 @@ func (a *Autofill) generateFromTag(tag string, field reflect.StructField, ctx *c
 			fmt.Sscanf(minStr, "%d", &min)
 			fmt.Sscanf(maxStr, "%d", &max)
 			if min <= max {
-				rangeSize := max - min + 1
-				return min + (ctx.Index() % rangeSize), nil
+				ctx.record(OriginMinMax, "", map[string]string{"min": minStr, "max": maxStr})
+				if ctx.EdgeCase() {
+					return []int{min, max}[ctx.Rand().Intn(2)], nil
+				}
+				return min + pick(ctx, max-min+1), nil
 			}
 		}
 	}
@@ -89,7 +105,11 @@ func (a *Autofill) generateFromTag(tag string, field reflect.StructField, ctx *c
 	if oneofStr, ok := params["oneof"]; ok {
 		options := strings.Split(oneofStr, "|")
 		if len(options) > 0 {
-			return options[ctx.Index()%len(options)], nil
+			ctx.record(OriginOneOf, "", map[string]string{"oneof": oneofStr})
+			if ctx.EdgeCase() {
+				return []string{options[0], options[len(options)-1]}[ctx.Rand().Intn(2)], nil
+			}
+			return options[pick(ctx, len(options))], nil
 		}
 	}
 
@@ -108,8 +128,52 @@ func parseTagParams(parts []string) map[string]string {
 	return params
 }
 
-// generateByType generates a value based on the reflect.Type.
+// pick returns a value in [0, n).
+// In random mode it is drawn from the context's random number generator,
+// otherwise it is derived from the index so that values cycle through n.
+func pick(ctx *context, n int) int {
+	if ctx.Randomized() {
+		return ctx.Rand().Intn(n)
+	}
+	return ctx.Index() % n
+}
+
+// number returns a non-negative number used to make generated values unique.
+// It is the index in sequential mode and a random number in random mode.
+func number(ctx *context) int {
+	if ctx.Randomized() {
+		return ctx.Rand().Intn(100000)
+	}
+	return ctx.Index()
+}
+
+// generateByType generates a value based on the reflect.Type,
+// unless there is a type override for it.
 func (a *Autofill) generateByType(typ reflect.Type, ctx *context) (interface{}, error) {
+	if val, ok, err := a.generateTypeOverride(typ, ctx); ok || err != nil {
+		return val, err
+	}
+	return a.generateByKind(typ, ctx)
+}
+
+// generateByKind generates a value based on the kind of the reflect.Type.
+func (a *Autofill) generateByKind(typ reflect.Type, ctx *context) (interface{}, error) {
+	if val, ok := a.generateEdgeCase(typ, ctx); ok {
+		ctx.record(OriginEdgeCase, typ.String(), nil)
+		return val, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		// Recorded for their elements
+	case reflect.Struct:
+		if typ == reflect.TypeOf(time.Time{}) {
+			ctx.record(OriginType, typ.String(), nil)
+		}
+	default:
+		ctx.record(OriginType, typ.String(), nil)
+	}
+
 	switch typ.Kind() {
 	case reflect.String:
 		return a.generateString(ctx), nil
@@ -134,6 +198,9 @@ func (a *Autofill) generateByType(typ reflect.Type, ctx *context) (interface{},
 	case reflect.Slice:
 		// Generate a slice with 3 elements by default
 		return a.generateSlice(typ, ctx, 3)
+	case reflect.Map:
+		// Generate a map with 3 entries by default
+		return a.generateMap(typ, ctx, 3)
 	case reflect.Struct:
 		// Special handling for time.Time
 		if typ == reflect.TypeOf(time.Time{}) {
@@ -149,37 +216,34 @@ func (a *Autofill) generateByType(typ reflect.Type, ctx *context) (interface{},
 // generateString generates a random string.
 func (a *Autofill) generateString(ctx *context) string {
 	words := []string{"hello", "world", "test", "sample", "data", "value", "string", "text"}
-	return words[ctx.Index()%len(words)]
+	return words[pick(ctx, len(words))]
 }
 
 // generateInt generates a random integer.
 func (a *Autofill) generateInt(ctx *context) int64 {
-	return int64(100 + (ctx.Index() % 900))
+	return int64(100 + pick(ctx, 900))
 }
 
 // generateUint generates a random unsigned integer.
 func (a *Autofill) generateUint(ctx *context) uint64 {
-	return uint64(100 + (ctx.Index() % 900))
+	return uint64(100 + pick(ctx, 900))
 }
 
 // generateFloat generates a random float.
 func (a *Autofill) generateFloat(ctx *context) float64 {
-	seed := ctx.Seed() + int64(ctx.Index())
-	rng := rand.New(rand.NewSource(seed))
-	return rng.Float64() * 1000
+	return ctx.Rand().Float64() * 1000
 }
 
 // generateBool generates a random boolean.
 func (a *Autofill) generateBool(ctx *context) bool {
-	return ctx.Index()%2 == 0
+	return pick(ctx, 2) == 0
 }
 
 // generateTime generates a random time.
 func (a *Autofill) generateTime(ctx *context) time.Time {
 	// Generate a time within the last year
-	now := time.Now()
-	daysAgo := ctx.Index() % 365
-	return now.AddDate(0, 0, -daysAgo)
+	daysAgo := pick(ctx, 365)
+	return ctx.Now().AddDate(0, 0, -daysAgo)
 }
 
 // generateEmail generates an email address.
@@ -187,10 +251,10 @@ func (a *Autofill) generateEmail(ctx *context) string {
 	domains := []string{"example.com", "test.com", "mail.com"}
 	prefixes := []string{"user", "test", "demo", "sample"}
 
-	prefix := prefixes[ctx.Index()%len(prefixes)]
-	domain := domains[ctx.Index()%len(domains)]
+	prefix := prefixes[pick(ctx, len(prefixes))]
+	domain := domains[pick(ctx, len(domains))]
 
-	return fmt.Sprintf("%s%d@%s", prefix, ctx.Index(), domain)
+	return fmt.Sprintf("%s%d@%s", prefix, number(ctx), domain)
 }
 
 // generateURL generates a URL.
@@ -198,19 +262,16 @@ func (a *Autofill) generateURL(ctx *context) string {
 	domains := []string{"example.com", "test.com", "demo.org"}
 	paths := []string{"/", "/home", "/about", "/contact"}
 
-	domain := domains[ctx.Index()%len(domains)]
-	path := paths[ctx.Index()%len(paths)]
+	domain := domains[pick(ctx, len(domains))]
+	path := paths[pick(ctx, len(paths))]
 
 	return fmt.Sprintf("https://%s%s", domain, path)
 }
 
 // generateUUID generates a UUID.
 func (a *Autofill) generateUUID(ctx *context) string {
-	seed := ctx.Seed() + int64(ctx.Index())
-	rng := rand.New(rand.NewSource(seed))
-
 	var uuidBytes [16]byte
-	rng.Read(uuidBytes[:])
+	ctx.Rand().Read(uuidBytes[:])
 
 	// Set version (4) and variant bits
 	uuidBytes[6] = (uuidBytes[6] & 0x0f) | 0x40
@@ -225,45 +286,100 @@ func (a *Autofill) generateUUID(ctx *context) string {
 }
 
 // generateSlice generates a slice of the given type and length.
+// Overrides in ctx are keyed by element, e.g. "[0].Name" or "[*].Price".
 func (a *Autofill) generateSlice(typ reflect.Type, ctx *context, length int) (interface{}, error) {
 	slice := reflect.MakeSlice(typ, length, length)
-	elemType := typ.Elem()
+	scoped := scopeOverrides(ctx.overrides)
+	if err := checkIndexes(ctx, scoped, length); err != nil {
+		return nil, err
+	}
 
 	for i := 0; i < length; i++ {
-		elemCtx := ctx.withIndex(i)
-		val, err := a.generateByType(elemType, elemCtx)
-		if err != nil {
+		elemCtx := ctx.withElement(i)
+		if err := a.generateElement(slice.Index(i), elemCtx, scoped.element(strconv.Itoa(i))); err != nil {
 			return nil, fmt.Errorf("failed to generate slice element at index %d: %w", i, err)
 		}
-		slice.Index(i).Set(reflect.ValueOf(val).Convert(elemType))
 	}
 
 	return slice.Interface(), nil
 }
 
-// fillStructValue fills a struct value and returns it.
-func (a *Autofill) fillStructValue(typ reflect.Type, ctx *context) (interface{}, error) {
-	structVal := reflect.New(typ).Elem()
-	structCtx := ctx.withStruct(structVal.Addr().Interface())
+// generateMap generates a map of the given type and length.
+// Overrides in ctx are keyed by map key, e.g. "[key]" or "[*].Name",
+// and entries are added for override keys that were not generated.
+func (a *Autofill) generateMap(typ reflect.Type, ctx *context, length int) (interface{}, error) {
+	m := reflect.MakeMapWithSize(typ, length)
+	keyType, elemType := typ.Key(), typ.Elem()
+	scoped := scopeOverrides(ctx.overrides)
 
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
-		fieldVal := structVal.Field(i)
+	for i := 0; i < length; i++ {
+		keyCtx := ctx.withElement(i)
+		keyCtx.trace = nil // keys are not values of the map
+		key, err := a.generateByType(keyType, keyCtx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate map key at index %d: %w", i, err)
+		}
+		keyVal := reflect.ValueOf(key).Convert(keyType)
+		m.SetMapIndex(keyVal, reflect.Zero(elemType))
+	}
 
-		if !fieldVal.CanSet() {
+	// Add entries for keys that are only present in overrides
+	for name := range scoped {
+		if name == "*" {
 			continue
 		}
-
-		fieldCtx := structCtx.withFieldName(field.Name)
-		val, err := a.generateValue(field, fieldCtx)
+		keyVal, err := parseMapKey(name, keyType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate field %s: %w", field.Name, err)
+			return nil, err
 		}
+		m.SetMapIndex(keyVal, reflect.Zero(elemType))
+	}
 
-		if val != nil {
-			fieldVal.Set(reflect.ValueOf(val).Convert(field.Type))
+	for i, keyVal := range sortedMapKeys(m) {
+		name := fmt.Sprint(keyVal.Interface())
+		elem := reflect.New(elemType).Elem()
+		if err := a.generateElement(elem, ctx.withKey(i, name), scoped.element(name)); err != nil {
+			return nil, fmt.Errorf("failed to generate map value for key %s: %w", name, err)
+		}
+		m.SetMapIndex(keyVal, elem)
+	}
+
+	return m.Interface(), nil
+}
+
+// generateElement sets dst, a slice element or map value, to its override
+// if there is one, or to a generated value otherwise.
+func (a *Autofill) generateElement(dst reflect.Value, ctx *context, scope overrideScope) error {
+	nested := scope.nested
+	if scope.hasValue {
+		resolved, err := resolveOverride(scope.value, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to resolve override for %s: %w", ctx.Path(), err)
+		}
+		if sub, ok := resolved.(Override); ok {
+			nested = mergeOverrides([]Override{sub, nested})
+		} else if resolved != nil {
+			if err := setFieldValue(dst, resolved); err != nil {
+				return fmt.Errorf("failed to set override for %s: %w", ctx.Path(), err)
+			}
+			ctx.recordOverride(scope.value)
+			return a.applyOverrides(dst, ctx.withOverrides(nested))
 		}
 	}
 
+	val, err := a.generateByType(dst.Type(), ctx.withOverrides(nested))
+	if err != nil {
+		return err
+	}
+	dst.Set(reflect.ValueOf(val).Convert(dst.Type()))
+	return nil
+}
+
+// fillStructValue fills a struct value and returns it.
+func (a *Autofill) fillStructValue(typ reflect.Type, ctx *context) (interface{}, error) {
+	structVal := reflect.New(typ).Elem()
+	if err := a.fillStruct(structVal, ctx); err != nil {
+		return nil, err
+	}
 	return structVal.Interface(), nil
 }
@@ -2,4 +2,7 @@ module github.com/m1a9s9a4/autofill
 
 go 1.24.4
 
-require github.com/google/uuid v1.6.0 // indirect
+require (
+	github.com/google/uuid v1.6.0
+	gopkg.in/yaml.v3 v3.0.1
+)
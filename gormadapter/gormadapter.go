@@ -0,0 +1,311 @@
+// Package gormadapter creates values filled by autofill with GORM.
+//
+// It is a separate module, so that autofill itself does not depend on GORM:
+//
+//	go get github.com/m1a9s9a4/autofill/gormadapter
+//
+// The adapter reads the GORM schema of a model to fill it the way GORM
+// expects: auto-increment primary keys and foreign keys are left for GORM
+// and the database to assign, belongs-to and has-many associations are
+// filled so that db.Create creates them too, unique columns get a value
+// derived from the index, and strings are cut to the size of their column.
+//
+// Other constraints are not enforced: not null, check and default tags and
+// unique indexes over several columns are ignored.
+//
+//	orders, err := gormadapter.NewFactory[Order](nil, db)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	order, err := orders.Create(ctx) // the order, its customer and its items
+package gormadapter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/m1a9s9a4/autofill"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// maxDepth is the number of nested associations that are filled.
+// Deeper associations are left empty.
+const maxDepth = 3
+
+// Persister is an autofill.Persister that creates models with db.Create.
+// GORM also creates the associations of the model and writes the
+// generated primary and foreign keys back.
+type Persister struct {
+	db *gorm.DB
+}
+
+// New creates a Persister that creates models with db.
+func New(db *gorm.DB) *Persister {
+	return &Persister{db: db}
+}
+
+// Persist creates the model pointed to by v.
+func (p *Persister) Persist(ctx context.Context, v interface{}) error {
+	return p.db.WithContext(ctx).Create(v).Error
+}
+
+// NewFactory creates an autofill.Factory for the model T that fills values
+// with Defaults, applies Constrain after every build and creates values
+// with New(db). If a is nil, autofill.New() is used.
+func NewFactory[T any](a *autofill.Autofill, db *gorm.DB, overrides ...autofill.Override) (*autofill.Factory[T], error) {
+	defaults, err := Defaults[T](db, overrides...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := parse(db, new(T))
+	if err != nil {
+		return nil, err
+	}
+
+	return autofill.NewFactory[T](a).
+		WithDefaults(defaults).
+		WithPersister(New(db)).
+		AfterBuild(func(v *T) { constrain(s, reflect.ValueOf(v).Elem(), 0) }), nil
+}
+
+// Defaults returns overrides for the model T derived from its GORM schema,
+// merged with overrides:
+//
+//   - auto-increment primary keys are zero, so the database assigns them
+//   - foreign keys of belongs-to, has-one and has-many associations are zero,
+//     so GORM sets them when creating the associations
+//   - many-to-many associations, associations that refer back to a model
+//     being filled and associations nested deeper than three levels are empty
+//   - string and integer columns that are unique, from `gorm:"unique"`,
+//     a single-column `gorm:"uniqueIndex"` or a primary key that is not
+//     auto-incremented, are derived from the index, e.g. "email-3" and 4,
+//     so that the values of a factory's builds do not collide. In has-many
+//     associations the index is that of the element, so the values are only
+//     distinct within one build
+func Defaults[T any](db *gorm.DB, overrides ...autofill.Override) (autofill.Override, error) {
+	s, err := parse(db, new(T))
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := autofill.Override{}
+	schemaDefaults(s, "", nil, defaults)
+	for _, override := range overrides {
+		for k, v := range override {
+			defaults[k] = v
+		}
+	}
+	return defaults, nil
+}
+
+// schemaDefaults adds the defaults of the model s at prefix to defaults.
+// stack holds the models being filled, from the root to s.
+func schemaDefaults(s *schema.Schema, prefix string, stack []*schema.Schema, defaults autofill.Override) {
+	stack = append(stack, s)
+	unique := uniqueFields(s)
+	for _, field := range s.Fields {
+		if field.PrimaryKey && field.AutoIncrement {
+			defaults[prefix+field.Name] = reflect.Zero(field.FieldType).Interface()
+		} else if seq := uniqueSeq(field); seq != nil && unique[field] {
+			defaults[prefix+field.Name] = seq
+		}
+	}
+
+	for _, rel := range relationships(s) {
+		key := prefix + rel.Name
+		if rel.Type == schema.Many2Many || len(stack) > maxDepth || contains(stack, rel.FieldSchema) {
+			defaults[key] = reflect.Zero(rel.Field.FieldType).Interface()
+			continue
+		}
+
+		nested := key + "."
+		if rel.Field.FieldType.Kind() == reflect.Slice {
+			nested = key + "[*]."
+		}
+		for _, ref := range rel.References {
+			if ref.ForeignKey == nil {
+				continue
+			}
+			if ref.OwnPrimaryKey {
+				defaults[nested+ref.ForeignKey.Name] = reflect.Zero(ref.ForeignKey.FieldType).Interface()
+			} else if ref.ForeignKey.Schema == s {
+				defaults[prefix+ref.ForeignKey.Name] = reflect.Zero(ref.ForeignKey.FieldType).Interface()
+			}
+		}
+		schemaDefaults(rel.FieldSchema, nested, stack, defaults)
+	}
+}
+
+// uniqueFields returns the fields of s whose column is unique on its own.
+func uniqueFields(s *schema.Schema) map[*schema.Field]bool {
+	unique := make(map[*schema.Field]bool)
+	for _, field := range s.Fields {
+		if field.Unique {
+			unique[field] = true
+		}
+	}
+	for _, index := range s.ParseIndexes() {
+		if index.Class == "UNIQUE" && len(index.Fields) == 1 {
+			unique[index.Fields[0].Field] = true
+		}
+	}
+	if len(s.PrimaryFields) == 1 && !s.PrimaryFields[0].AutoIncrement {
+		unique[s.PrimaryFields[0]] = true
+	}
+	return unique
+}
+
+// uniqueSeq returns a sequence of distinct values for the string or integer
+// column of field, or nil for other types. Strings are the column name and
+// the index, shortened to fit the column size; integers start at 1.
+func uniqueSeq(field *schema.Field) autofill.SequenceFunc {
+	typ := field.FieldType
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		size := columnSize(field)
+		return func(index int) interface{} {
+			suffix := "-" + strconv.Itoa(index)
+			name := []rune(field.DBName)
+			if size > 0 && len(name)+len(suffix) > size {
+				name = name[:max(size-len(suffix), 0)]
+			}
+			return reflect.ValueOf(string(name) + suffix).Convert(typ).Interface()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(index int) interface{} {
+			return reflect.ValueOf(index + 1).Convert(typ).Interface()
+		}
+	}
+	return nil
+}
+
+// Constrain adjusts the model pointed to by v, and its associations, to the
+// column constraints of its GORM schema: strings are cut to the size of
+// their column, from `gorm:"size:N"` or `gorm:"type:varchar(N)"`, and floats
+// are rounded to the scale of their column, from `gorm:"scale:N"`.
+// Values are only adjusted after they are filled; other constraints, such as
+// not null, unique, check and default, are not checked.
+func Constrain(db *gorm.DB, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Constrain requires a pointer to struct, got %T", v)
+	}
+	s, err := parse(db, v)
+	if err != nil {
+		return err
+	}
+	constrain(s, rv.Elem(), 0)
+	return nil
+}
+
+var sizedType = regexp.MustCompile(`(?i)^\s*(?:var)?char(?:acter)?(?:\s+varying)?\s*\((\d+)\)`)
+
+// constrain adjusts the struct v of model s and its associations.
+func constrain(s *schema.Schema, v reflect.Value, depth int) {
+	ctx := context.Background()
+	for _, field := range s.Fields {
+		if field.StructField.Type == nil || !field.Readable || field.FieldType == nil {
+			continue
+		}
+		fv := field.ReflectValueOf(ctx, v)
+		for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if !fv.CanSet() {
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.String:
+			if size := columnSize(field); size > 0 {
+				if r := []rune(fv.String()); len(r) > size {
+					fv.SetString(string(r[:size]))
+				}
+			}
+		case reflect.Float32, reflect.Float64:
+			if field.Scale > 0 {
+				pow := math.Pow(10, float64(field.Scale))
+				fv.SetFloat(math.Round(fv.Float()*pow) / pow)
+			}
+		}
+	}
+
+	if depth >= maxDepth {
+		return
+	}
+	for _, rel := range relationships(s) {
+		rv := rel.Field.ReflectValueOf(ctx, v)
+		switch rv.Kind() {
+		case reflect.Slice:
+			for i := 0; i < rv.Len(); i++ {
+				constrainValue(rel.FieldSchema, rv.Index(i), depth+1)
+			}
+		default:
+			constrainValue(rel.FieldSchema, rv, depth+1)
+		}
+	}
+}
+
+// constrainValue adjusts v, a struct or a pointer to one, of model s.
+func constrainValue(s *schema.Schema, v reflect.Value, depth int) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		constrain(s, v, depth)
+	}
+}
+
+// columnSize returns the maximum length of the string column of field, or 0.
+func columnSize(field *schema.Field) int {
+	if field.Size > 0 {
+		return field.Size
+	}
+	if m := sizedType.FindStringSubmatch(string(field.DataType)); m != nil {
+		size, _ := strconv.Atoi(m[1])
+		return size
+	}
+	return 0
+}
+
+// parse parses the GORM schema of model with the cache and naming strategy of db.
+func parse(db *gorm.DB, model interface{}) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, fmt.Errorf("failed to parse GORM schema of %T: %w", model, err)
+	}
+	return stmt.Schema, nil
+}
+
+// relationships returns the associations of s in a stable order.
+func relationships(s *schema.Schema) []*schema.Relationship {
+	var rels []*schema.Relationship
+	rels = append(rels, s.Relationships.BelongsTo...)
+	rels = append(rels, s.Relationships.HasOne...)
+	rels = append(rels, s.Relationships.HasMany...)
+	rels = append(rels, s.Relationships.Many2Many...)
+	return rels
+}
+
+// contains reports whether stack contains s.
+func contains(stack []*schema.Schema, s *schema.Schema) bool {
+	for _, other := range stack {
+		if other == s {
+			return true
+		}
+	}
+	return false
+}
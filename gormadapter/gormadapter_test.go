@@ -0,0 +1,235 @@
+package gormadapter
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/glebarez/sqlite"
+	"github.com/m1a9s9a4/autofill"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type Customer struct {
+	ID     uint
+	Name   string `gorm:"size:8"`
+	Orders []Order
+}
+
+type Order struct {
+	ID         uint
+	Number     string  `gorm:"type:varchar(6)"`
+	Total      float64 `gorm:"scale:2"`
+	CustomerID uint
+	Customer   Customer
+	Items      []OrderItem
+	Tags       []Tag `gorm:"many2many:order_tags"`
+}
+
+type OrderItem struct {
+	ID      uint
+	OrderID uint
+	Order   *Order
+	SKU     string
+	Qty     int
+}
+
+type Tag struct {
+	ID   uint
+	Name string
+}
+
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.AutoMigrate(&Customer{}, &Order{}, &OrderItem{}, &Tag{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestDefaults(t *testing.T) {
+	db := openDB(t)
+
+	defaults, err := Defaults[Order](db, autofill.Override{"Number": "A1"})
+	if err != nil {
+		t.Fatalf("Defaults failed: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"ID":               uint(0),
+		"CustomerID":       uint(0),
+		"Customer.ID":      uint(0),
+		"Customer.Orders":  []Order(nil),
+		"Items[*].ID":      uint(0),
+		"Items[*].OrderID": uint(0),
+		"Items[*].Order":   (*Order)(nil),
+		"Tags":             []Tag(nil),
+		"Number":           "A1",
+	}
+	for key, value := range want {
+		got, ok := defaults[key]
+		if !ok {
+			t.Errorf("expected key %q in %v", key, defaults)
+			continue
+		}
+		if !reflect.DeepEqual(got, value) {
+			t.Errorf("%s: expected %#v, got %#v", key, value, got)
+		}
+	}
+}
+
+func TestFactory_Create(t *testing.T) {
+	db := openDB(t)
+	orders, err := NewFactory[Order](autofill.New().WithSeed(7), db)
+	if err != nil {
+		t.Fatalf("NewFactory failed: %v", err)
+	}
+
+	order, err := orders.Create(context.Background())
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if order.ID == 0 || order.Customer.ID == 0 || order.CustomerID != order.Customer.ID {
+		t.Errorf("expected generated IDs to be written back, got %+v", order)
+	}
+	if len(order.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(order.Items))
+	}
+	for _, item := range order.Items {
+		if item.ID == 0 || item.OrderID != order.ID {
+			t.Errorf("expected item of order %d, got %+v", order.ID, item)
+		}
+	}
+
+	var customers, items, tags int64
+	db.Model(&Customer{}).Count(&customers)
+	db.Model(&OrderItem{}).Count(&items)
+	db.Model(&Tag{}).Count(&tags)
+	if customers != 1 || items != 3 || tags != 0 {
+		t.Errorf("expected 1 customer, 3 items and no tags, got %d, %d and %d", customers, items, tags)
+	}
+
+	var stored Order
+	if err := db.Preload("Items").First(&stored, order.ID).Error; err != nil {
+		t.Fatalf("failed to load order: %v", err)
+	}
+	if stored.Number != order.Number || len(stored.Items) != 3 {
+		t.Errorf("expected the stored order to match, got %+v", stored)
+	}
+}
+
+func TestConstrain(t *testing.T) {
+	db := openDB(t)
+
+	order := Order{
+		Number:   "ABCDEFGHIJ",
+		Total:    12.3456,
+		Customer: Customer{Name: "Bartholomew"},
+	}
+	if err := Constrain(db, &order); err != nil {
+		t.Fatalf("Constrain failed: %v", err)
+	}
+	if order.Number != "ABCDEF" {
+		t.Errorf("expected Number to be cut to 6 characters, got %q", order.Number)
+	}
+	if math.Abs(order.Total-12.35) > 1e-9 {
+		t.Errorf("expected Total to be rounded to 12.35, got %v", order.Total)
+	}
+	if utf8.RuneCountInString(order.Customer.Name) != 8 {
+		t.Errorf("expected Customer.Name to be cut to 8 characters, got %q", order.Customer.Name)
+	}
+
+	if err := Constrain(db, order); err == nil {
+		t.Error("expected an error for a non-pointer value")
+	}
+}
+
+type Member struct {
+	ID     uint
+	Email  string `gorm:"uniqueIndex;size:32"`
+	Code   string `gorm:"unique;size:6"`
+	Number *int64 `gorm:"unique"`
+}
+
+type Seat struct {
+	ID   uint
+	Team string `gorm:"index:idx_team_role,unique"`
+	Role string `gorm:"index:idx_team_role,unique"`
+}
+
+func TestDefaults_UniqueColumns(t *testing.T) {
+	db := openDB(t)
+
+	defaults, err := Defaults[Member](db)
+	if err != nil {
+		t.Fatalf("Defaults failed: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"Email":  "email-3",
+		"Code":   "cod-12",
+		"Number": int64(4),
+	}
+	for key, value := range want {
+		seq, ok := defaults[key].(autofill.SequenceFunc)
+		if !ok {
+			t.Errorf("expected a sequence for %s, got %#v", key, defaults[key])
+			continue
+		}
+		index := 3
+		if key == "Code" {
+			index = 12
+		}
+		if got := seq(index); got != value {
+			t.Errorf("%s: expected %#v, got %#v", key, value, got)
+		}
+	}
+
+	seats, err := Defaults[Seat](db)
+	if err != nil {
+		t.Fatalf("Defaults failed: %v", err)
+	}
+	for _, key := range []string{"Team", "Role"} {
+		if _, ok := seats[key]; ok {
+			t.Errorf("expected no default for %s, which is unique only together with another column", key)
+		}
+	}
+}
+
+func TestFactory_UniqueColumns(t *testing.T) {
+	db := openDB(t)
+	if err := db.AutoMigrate(&Member{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	members, err := NewFactory[Member](autofill.New().WithMode(autofill.SequentialMode), db)
+	if err != nil {
+		t.Fatalf("NewFactory failed: %v", err)
+	}
+	// Sequential strings repeat after a few builds without unique defaults.
+	for i := 0; i < 20; i++ {
+		if _, err := members.Create(context.Background()); err != nil {
+			t.Fatalf("Create %d failed: %v", i, err)
+		}
+	}
+
+	var count int64
+	db.Model(&Member{}).Count(&count)
+	if count != 20 {
+		t.Errorf("expected 20 members, got %d", count)
+	}
+}
@@ -0,0 +1,97 @@
+package autofill
+
+import (
+	"reflect"
+	"testing"
+)
+
+type modeUser struct {
+	ID     int64 `autofill:"seq"`
+	Name   string
+	Email  string `autofill:"email"`
+	Age    int    `autofill:"min=18,max=65"`
+	Status string `autofill:"oneof=active|inactive|pending"`
+	Active bool
+	Score  uint
+	Tags   []string
+}
+
+func TestWithMode(t *testing.T) {
+	af := New()
+	if af.mode != SequentialMode {
+		t.Errorf("expected default mode SequentialMode, got %v", af.mode)
+	}
+
+	af.WithMode(RandomMode)
+	if af.mode != RandomMode {
+		t.Errorf("expected mode RandomMode, got %v", af.mode)
+	}
+}
+
+func TestRandomMode_SameSeedSameData(t *testing.T) {
+	users1 := make([]modeUser, 10)
+	if err := New().WithSeed(42).WithMode(RandomMode).FillSlice(&users1); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	users2 := make([]modeUser, 10)
+	if err := New().WithSeed(42).WithMode(RandomMode).FillSlice(&users2); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(users1, users2) {
+		t.Errorf("expected same data for same seed, got %+v and %+v", users1, users2)
+	}
+}
+
+func TestRandomMode_SeedChangesData(t *testing.T) {
+	users1 := make([]modeUser, 10)
+	if err := New().WithSeed(1).WithMode(RandomMode).FillSlice(&users1); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	users2 := make([]modeUser, 10)
+	if err := New().WithSeed(2).WithMode(RandomMode).FillSlice(&users2); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	if reflect.DeepEqual(users1, users2) {
+		t.Error("expected different data for different seeds")
+	}
+}
+
+func TestRandomMode_RespectsConstraints(t *testing.T) {
+	users := make([]modeUser, 50)
+	if err := New().WithSeed(7).WithMode(RandomMode).FillSlice(&users); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	for i, u := range users {
+		if u.ID != int64(i) {
+			t.Errorf("user %d: expected seq ID %d, got %d", i, i, u.ID)
+		}
+		if u.Age < 18 || u.Age > 65 {
+			t.Errorf("user %d: Age %d is out of range [18, 65]", i, u.Age)
+		}
+		if u.Status != "active" && u.Status != "inactive" && u.Status != "pending" {
+			t.Errorf("user %d: unexpected Status %s", i, u.Status)
+		}
+		if u.Score < 100 || u.Score > 999 {
+			t.Errorf("user %d: Score %d is out of range [100, 999]", i, u.Score)
+		}
+	}
+}
+
+func TestSequentialMode_IgnoresSeed(t *testing.T) {
+	var user1, user2 modeUser
+	if err := New().WithSeed(1).Fill(&user1); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if err := New().WithSeed(2).Fill(&user2); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(user1, user2) {
+		t.Errorf("expected sequential mode to ignore the seed, got %+v and %+v", user1, user2)
+	}
+}
@@ -1,17 +1,51 @@
 package autofill
 
-import "fmt"
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Override represents a map of field names to their override values.
 // Values can be:
 // - Direct values: any type that matches the field type
 // - SequenceFunc: a function that generates values based on index
+// - OverrideFunc: a function that generates values based on the Context
 type Override map[string]interface{}
 
 // SequenceFunc is a function that generates a value based on an index.
 // It is called for each element when filling slices.
 type SequenceFunc func(index int) interface{}
 
+// OverrideFunc is a function that generates a value based on the Context.
+// It can read sibling fields with GetField, draw from the seeded Rand, and use
+// the locale, index and field path. Fields overridden by an OverrideFunc are
+// filled after the other fields of their struct, in field order, so GetField
+// sees their values.
+// A returned error is reported as an error for the field.
+type OverrideFunc func(ctx Context) (interface{}, error)
+
+// From creates an OverrideFunc from a function that cannot fail.
+//
+// Example:
+//
+//	autofill.Override{
+//	    "Slug": autofill.From(func(ctx autofill.Context) interface{} {
+//	        title, _ := ctx.GetField("Title")
+//	        return slugify(title.(string))
+//	    }),
+//	}
+func From(fn func(ctx Context) interface{}) OverrideFunc {
+	return func(ctx Context) (interface{}, error) {
+		return fn(ctx), nil
+	}
+}
+
 // Seq creates a SequenceFunc that formats a string with the current index.
 // The format string should contain a single %d placeholder for the index.
 //
@@ -46,24 +80,188 @@ func SeqInt64(start int64) SequenceFunc {
 	}
 }
 
-// Random creates a SequenceFunc that generates random integers between min and max (inclusive).
+// SeqFloat creates a SequenceFunc that generates float64 values starting from start,
+// increasing by step.
+//
+// Example:
+//
+//	autofill.SeqFloat(9.99, 0.5) // generates 9.99, 10.49, 10.99, etc.
+func SeqFloat(start, step float64) SequenceFunc {
+	return func(index int) interface{} {
+		return start + float64(index)*step
+	}
+}
+
+// SeqTime creates a SequenceFunc that generates times starting from start,
+// increasing by step.
+//
+// Example:
+//
+//	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	autofill.SeqTime(start, time.Hour) // generates 00:00, 01:00, 02:00, etc.
+func SeqTime(start time.Time, step time.Duration) SequenceFunc {
+	return func(index int) interface{} {
+		return start.Add(time.Duration(index) * step)
+	}
+}
+
+// SeqFormat creates a SequenceFunc that formats a string with the values of
+// several sequences, one per placeholder.
+//
+// Example:
+//
+//	autofill.SeqFormat("%s-%04d", autofill.Cycle("INV", "CRN"), autofill.SeqInt(1))
+//	// generates INV-0001, CRN-0002, INV-0003, etc.
+func SeqFormat(format string, args ...SequenceFunc) SequenceFunc {
+	return func(index int) interface{} {
+		values := make([]interface{}, len(args))
+		for i, arg := range args {
+			values[i] = arg(index)
+		}
+		return fmt.Sprintf(format, values...)
+	}
+}
+
+// Cycle creates a SequenceFunc that repeats values in order.
+// It panics if no values are given.
+//
+// Example:
+//
+//	autofill.Cycle("admin", "editor", "viewer") // generates admin, editor, viewer, admin, etc.
+func Cycle(values ...interface{}) SequenceFunc {
+	if len(values) == 0 {
+		panic("autofill: Cycle requires at least one value")
+	}
+	return func(index int) interface{} {
+		return values[index%len(values)]
+	}
+}
+
+// Shuffle creates an OverrideFunc that repeats values in a shuffled order.
+// Every len(values) consecutive indexes receive each value exactly once.
+// The order is derived from the seed and the field path, e.g. "Home.City",
+// so it is reproducible with WithSeed and differs between fields of the
+// same name. It panics if no values are given.
+//
+// Example:
+//
+//	autofill.Shuffle("red", "green", "blue") // e.g. green, blue, red, blue, red, green, etc.
+func Shuffle(values ...interface{}) OverrideFunc {
+	if len(values) == 0 {
+		panic("autofill: Shuffle requires at least one value")
+	}
+	return func(ctx Context) (interface{}, error) {
+		round, pos := ctx.Index()/len(values), ctx.Index()%len(values)
+
+		h := fnv.New64a()
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], uint64(ctx.Seed()))
+		h.Write(buf[:])
+		h.Write([]byte(shufflePath(ctx)))
+		binary.LittleEndian.PutUint64(buf[:], uint64(round))
+		h.Write(buf[:])
+
+		perm := rand.New(rand.NewSource(int64(h.Sum64()))).Perm(len(values))
+		return values[perm[pos]], nil
+	}
+}
+
+// shufflePath returns the path of the field in ctx with the index of its
+// element replaced by "*", e.g. "Items[*].Color" for "Items[2].Color", so that
+// all elements of a slice share one order.
+func shufflePath(ctx Context) string {
+	path := ctx.Path()
+	index := "[" + strconv.Itoa(ctx.Index()) + "]"
+	if i := strings.LastIndex(path, index); i >= 0 {
+		return path[:i] + "[*]" + path[i+len(index):]
+	}
+	return path
+}
+
+// Weighted creates an OverrideFunc that picks one of the keys of weights,
+// with a probability proportional to its weight. It draws from the seeded
+// random number generator, so it is reproducible with WithSeed.
+// It panics if a weight is negative, all weights are zero or two keys of
+// the same type print the same, which would make the order of keys unstable.
 //
 // Example:
 //
-//	autofill.Random(1, 100) // generates random integers between 1 and 100
+//	autofill.Weighted(map[interface{}]int{"active": 8, "suspended": 1, "deleted": 1})
+func Weighted(weights map[interface{}]int) OverrideFunc {
+	values := make([]interface{}, 0, len(weights))
+	total := 0
+	for value, weight := range weights {
+		if weight < 0 {
+			panic(fmt.Sprintf("autofill: Weighted got negative weight %d for %v", weight, value))
+		}
+		values = append(values, value)
+		total += weight
+	}
+	if total == 0 {
+		panic("autofill: Weighted requires a positive total weight")
+	}
+	// Sort values so that the same seed picks the same value regardless of map
+	// order. Keys are qualified by their type, so that 1 and "1" differ.
+	keys := make(map[interface{}]string, len(values))
+	for _, value := range values {
+		keys[value] = fmt.Sprintf("%T:%v", value, value)
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return keys[values[i]] < keys[values[j]]
+	})
+	for i := 1; i < len(values); i++ {
+		if keys[values[i]] == keys[values[i-1]] {
+			panic(fmt.Sprintf("autofill: Weighted got keys that print the same: %s", keys[values[i]]))
+		}
+	}
+
+	return func(ctx Context) (interface{}, error) {
+		n := ctx.Rand().Intn(total)
+		for _, value := range values {
+			if n < weights[value] {
+				return value, nil
+			}
+			n -= weights[value]
+		}
+		return values[len(values)-1], nil
+	}
+}
+
+// Random creates a SequenceFunc that generates integers between min and max (inclusive).
+//
+// Deprecated: Random cycles through the range by index; use RandomInt.
 func Random(min, max int) SequenceFunc {
 	return func(index int) interface{} {
-		// This will be seeded by the autofill context
 		return min + (index % (max - min + 1))
 	}
 }
 
-// resolveOverride resolves an override value for a given field and index.
+// RandomInt creates an OverrideFunc that generates random integers between min and max (inclusive).
+// It draws from the seeded random number generator, so it is reproducible with WithSeed.
+// It panics if max is less than min.
+//
+// Example:
+//
+//	autofill.RandomInt(1, 100) // generates random integers between 1 and 100
+func RandomInt(min, max int) OverrideFunc {
+	if max < min {
+		panic(fmt.Sprintf("autofill: RandomInt got max %d less than min %d", max, min))
+	}
+	return func(ctx Context) (interface{}, error) {
+		return min + ctx.Rand().Intn(max-min+1), nil
+	}
+}
+
+// resolveOverride resolves an override value for the field of the given context.
 // If the value is a SequenceFunc, it calls the function with the index.
+// If the value is an OverrideFunc, it calls the function with the context.
 // Otherwise, it returns the value as-is.
-func resolveOverride(value interface{}, index int) interface{} {
-	if fn, ok := value.(SequenceFunc); ok {
-		return fn(index)
+func resolveOverride(value interface{}, ctx Context) (interface{}, error) {
+	switch fn := value.(type) {
+	case SequenceFunc:
+		return fn(ctx.Index()), nil
+	case OverrideFunc:
+		return fn(ctx)
 	}
-	return value
+	return value, nil
 }
@@ -1,6 +1,13 @@
 package autofill
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestSeq(t *testing.T) {
 	fn := Seq("user%d@example.com")
@@ -65,6 +72,235 @@ func TestSeqInt64(t *testing.T) {
 	}
 }
 
+func TestSeqFloat(t *testing.T) {
+	fn := SeqFloat(1.5, 0.25)
+
+	tests := []struct {
+		index    int
+		expected float64
+	}{
+		{0, 1.5},
+		{1, 1.75},
+		{4, 2.5},
+	}
+
+	for _, tt := range tests {
+		result := fn(tt.index)
+		if result != tt.expected {
+			t.Errorf("SeqFloat(%d) = %v, expected %v", tt.index, result, tt.expected)
+		}
+	}
+}
+
+func TestSeqTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fn := SeqTime(start, time.Hour)
+
+	for _, index := range []int{0, 1, 24} {
+		result, ok := fn(index).(time.Time)
+		if !ok {
+			t.Fatalf("expected time.Time, got %T", fn(index))
+		}
+		if expected := start.Add(time.Duration(index) * time.Hour); !result.Equal(expected) {
+			t.Errorf("SeqTime(%d) = %v, expected %v", index, result, expected)
+		}
+	}
+}
+
+func TestSeqFormat(t *testing.T) {
+	fn := SeqFormat("%s-%04d", Cycle("INV", "CRN"), SeqInt(1))
+
+	expected := []string{"INV-0001", "CRN-0002", "INV-0003"}
+	for i, exp := range expected {
+		if result := fn(i); result != exp {
+			t.Errorf("SeqFormat(%d) = %v, expected %v", i, result, exp)
+		}
+	}
+}
+
+func TestCycle(t *testing.T) {
+	fn := Cycle("admin", "editor", "viewer")
+
+	expected := []string{"admin", "editor", "viewer", "admin", "editor"}
+	for i, exp := range expected {
+		if result := fn(i); result != exp {
+			t.Errorf("Cycle(%d) = %v, expected %v", i, result, exp)
+		}
+	}
+}
+
+func TestCycle_PanicsWithoutValues(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for Cycle without values")
+		}
+	}()
+	Cycle()
+}
+
+func TestShuffle(t *testing.T) {
+	type Item struct {
+		Color string
+	}
+
+	fill := func(seed int64) []Item {
+		items := make([]Item, 9)
+		if err := New().WithSeed(seed).FillSlice(&items, Override{"Color": Shuffle("red", "green", "blue")}); err != nil {
+			t.Fatalf("FillSlice failed: %v", err)
+		}
+		return items
+	}
+
+	items := fill(1)
+	for round := 0; round < 3; round++ {
+		seen := make(map[string]bool)
+		for _, item := range items[round*3 : round*3+3] {
+			seen[item.Color] = true
+		}
+		if len(seen) != 3 {
+			t.Errorf("round %d: expected each color once, got %v", round, items[round*3:round*3+3])
+		}
+	}
+
+	again := fill(1)
+	for i := range items {
+		if items[i].Color != again[i].Color {
+			t.Fatalf("expected same order for same seed, got %v and %v", items, again)
+		}
+	}
+
+	differs := false
+	for seed := int64(2); seed < 10 && !differs; seed++ {
+		other := fill(seed)
+		for i := range items {
+			if items[i].Color != other[i].Color {
+				differs = true
+			}
+		}
+	}
+	if !differs {
+		t.Error("expected different orders for different seeds")
+	}
+}
+
+func TestShuffle_FieldPath(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type Person struct {
+		Home   Address
+		Work   Address
+		Visits []Address
+	}
+
+	cities := []interface{}{"a", "b", "c", "d", "e", "f", "g", "h"}
+	people := make([]Person, len(cities))
+	err := New().WithSeed(1).FillSlice(&people, Override{
+		"Home.City":      Shuffle(cities...),
+		"Work.City":      Shuffle(cities...),
+		"Visits":         make([]Address, len(cities)),
+		"Visits[*].City": Shuffle(cities...),
+	})
+	if err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	sameOrder := true
+	for _, p := range people {
+		sameOrder = sameOrder && p.Home.City == p.Work.City
+	}
+	if sameOrder {
+		t.Error("expected Home.City and Work.City to be shuffled in different orders")
+	}
+
+	seen := make(map[string]bool)
+	for _, v := range people[0].Visits {
+		seen[v.City] = true
+	}
+	if len(seen) != len(cities) {
+		t.Errorf("expected each city once across the elements, got %v", people[0].Visits)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	type Account struct {
+		Status string
+	}
+
+	weights := map[interface{}]int{"active": 8, "suspended": 2, "deleted": 0}
+	fill := func() []Account {
+		accounts := make([]Account, 200)
+		if err := New().WithSeed(7).FillSlice(&accounts, Override{"Status": Weighted(weights)}); err != nil {
+			t.Fatalf("FillSlice failed: %v", err)
+		}
+		return accounts
+	}
+
+	accounts := fill()
+	counts := make(map[string]int)
+	for _, a := range accounts {
+		counts[a.Status]++
+	}
+	if counts["deleted"] != 0 {
+		t.Errorf("expected zero-weight value never to be picked, got %d", counts["deleted"])
+	}
+	if counts["active"] < 120 || counts["suspended"] < 10 {
+		t.Errorf("unexpected distribution %v", counts)
+	}
+
+	again := fill()
+	for i := range accounts {
+		if accounts[i].Status != again[i].Status {
+			t.Fatalf("index %d: expected same value for same seed, got %s and %s", i, accounts[i].Status, again[i].Status)
+		}
+	}
+}
+
+func TestWeighted_PanicsWithoutWeight(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for zero total weight")
+		}
+	}()
+	Weighted(map[interface{}]int{"a": 0})
+}
+
+func TestWeighted_TypedKeys(t *testing.T) {
+	pick := func() []interface{} {
+		values := make([]interface{}, 50)
+		fn := Weighted(map[interface{}]int{1: 1, "1": 1, int64(1): 1})
+		for i := range values {
+			ctx := newContext("en_US", 3, i, nil).withType(reflect.TypeOf(struct{}{})).withFieldName("Value")
+			v, err := fn(ctx)
+			if err != nil {
+				t.Fatalf("Weighted failed: %v", err)
+			}
+			values[i] = v
+		}
+		return values
+	}
+
+	first := pick()
+	for run := 0; run < 20; run++ {
+		if again := pick(); !reflect.DeepEqual(first, again) {
+			t.Fatalf("expected the same picks regardless of map order, got %v and %v", first, again)
+		}
+	}
+}
+
+type weightedLabel int
+
+func (weightedLabel) String() string { return "label" }
+
+func TestWeighted_PanicsOnKeysPrintingTheSame(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for keys that print the same")
+		}
+	}()
+	Weighted(map[interface{}]int{weightedLabel(1): 1, weightedLabel(2): 1})
+}
+
 func TestRandom(t *testing.T) {
 	fn := Random(1, 10)
 
@@ -80,9 +316,47 @@ func TestRandom(t *testing.T) {
 	}
 }
 
+func TestRandomInt(t *testing.T) {
+	type Player struct {
+		Score int
+	}
+
+	fill := func(seed int64) []Player {
+		players := make([]Player, 100)
+		if err := New().WithSeed(seed).FillSlice(&players, Override{"Score": RandomInt(1, 10)}); err != nil {
+			t.Fatalf("FillSlice failed: %v", err)
+		}
+		return players
+	}
+
+	players := fill(42)
+	sequential := true
+	for i, p := range players {
+		if p.Score < 1 || p.Score > 10 {
+			t.Errorf("player %d: Score %d is out of range [1, 10]", i, p.Score)
+		}
+		if p.Score != 1+i%10 {
+			sequential = false
+		}
+	}
+	if sequential {
+		t.Error("expected RandomInt not to follow the index")
+	}
+
+	again := fill(42)
+	for i := range players {
+		if players[i].Score != again[i].Score {
+			t.Fatalf("player %d: expected same Score for same seed, got %d and %d", i, players[i].Score, again[i].Score)
+		}
+	}
+}
+
 func TestResolveOverride_WithSequenceFunc(t *testing.T) {
 	fn := Seq("test%d")
-	result := resolveOverride(fn, 5)
+	result, err := resolveOverride(fn, newContext("en_US", 0, 5, nil))
+	if err != nil {
+		t.Fatalf("resolveOverride failed: %v", err)
+	}
 
 	expected := "test5"
 	if result != expected {
@@ -92,7 +366,10 @@ func TestResolveOverride_WithSequenceFunc(t *testing.T) {
 
 func TestResolveOverride_WithDirectValue(t *testing.T) {
 	value := "direct value"
-	result := resolveOverride(value, 5)
+	result, err := resolveOverride(value, newContext("en_US", 0, 5, nil))
+	if err != nil {
+		t.Fatalf("resolveOverride failed: %v", err)
+	}
 
 	if result != value {
 		t.Errorf("resolveOverride(direct, 5) = %v, expected %v", result, value)
@@ -101,13 +378,115 @@ func TestResolveOverride_WithDirectValue(t *testing.T) {
 
 func TestResolveOverride_WithInt(t *testing.T) {
 	value := 42
-	result := resolveOverride(value, 5)
+	result, err := resolveOverride(value, newContext("en_US", 0, 5, nil))
+	if err != nil {
+		t.Fatalf("resolveOverride failed: %v", err)
+	}
 
 	if result != value {
 		t.Errorf("resolveOverride(int, 5) = %v, expected %v", result, value)
 	}
 }
 
+func TestResolveOverride_WithOverrideFunc(t *testing.T) {
+	fn := OverrideFunc(func(ctx Context) (interface{}, error) {
+		return fmt.Sprintf("%s-%d", ctx.Locale(), ctx.Index()), nil
+	})
+	result, err := resolveOverride(fn, newContext("ja_JP", 0, 5, nil))
+	if err != nil {
+		t.Fatalf("resolveOverride failed: %v", err)
+	}
+
+	if result != "ja_JP-5" {
+		t.Errorf("resolveOverride(OverrideFunc, 5) = %v, expected ja_JP-5", result)
+	}
+}
+
+func TestOverrideFunc_ReadsSiblingFields(t *testing.T) {
+	type Article struct {
+		Slug  string
+		Title string
+		Path  string
+	}
+
+	articles := make([]Article, 3)
+	err := FillSlice(&articles, Override{
+		"Title": Seq("Hello World %d"),
+		"Slug": From(func(ctx Context) interface{} {
+			title, _ := ctx.GetField("Title")
+			return strings.ReplaceAll(strings.ToLower(title.(string)), " ", "-")
+		}),
+		"Path": From(func(ctx Context) interface{} {
+			slug, _ := ctx.GetField("Slug")
+			return "/articles/" + slug.(string)
+		}),
+	})
+	if err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	for i, a := range articles {
+		slug := fmt.Sprintf("hello-world-%d", i)
+		if a.Slug != slug {
+			t.Errorf("article %d: expected Slug %s, got %s", i, slug, a.Slug)
+		}
+		if a.Path != "/articles/"+slug {
+			t.Errorf("article %d: expected Path /articles/%s, got %s", i, slug, a.Path)
+		}
+	}
+}
+
+func TestOverrideFunc_NestedAndSeeded(t *testing.T) {
+	type Address struct {
+		City string
+		Code string
+	}
+	type Customer struct {
+		Address Address
+		Lucky   int
+	}
+
+	override := Override{
+		"Address.Code": From(func(ctx Context) interface{} {
+			city, _ := ctx.GetField("City")
+			return ctx.Path() + ":" + city.(string)
+		}),
+		"Lucky": From(func(ctx Context) interface{} {
+			return ctx.Rand().Intn(1000)
+		}),
+	}
+
+	var c1, c2 Customer
+	if err := New().WithSeed(3).Fill(&c1, override); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if err := New().WithSeed(3).Fill(&c2, override); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if c1.Address.Code != "Address.Code:"+c1.Address.City {
+		t.Errorf("expected Code derived from path and City, got %s", c1.Address.Code)
+	}
+	if c1.Lucky != c2.Lucky {
+		t.Errorf("expected seeded OverrideFunc to be reproducible, got %d and %d", c1.Lucky, c2.Lucky)
+	}
+}
+
+func TestOverrideFunc_Error(t *testing.T) {
+	var user TestUser
+	err := Fill(&user, Override{
+		"Name": OverrideFunc(func(ctx Context) (interface{}, error) {
+			return nil, errors.New("lookup failed")
+		}),
+	})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "field Name") || !strings.Contains(err.Error(), "lookup failed") {
+		t.Errorf("expected field error wrapping the function error, got %v", err)
+	}
+}
+
 func TestOverride_Integration(t *testing.T) {
 	type TestStruct struct {
 		Name  string
@@ -0,0 +1,206 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// overrideScope holds the overrides addressed to one field, slice element or map entry:
+// a value for the target itself and overrides for its nested fields.
+type overrideScope struct {
+	value    interface{}
+	hasValue bool
+	nested   Override
+}
+
+// scopedOverrides maps field names, slice indexes or map keys to their overrides.
+// The key "*" holds overrides addressed to every element, as in "Items[*].Price".
+type scopedOverrides map[string]overrideScope
+
+// scopeOverrides groups override keys by their first path segment.
+// For example, {"Address.City": "Tokyo", "Name": "John"} becomes
+// {"Address": {nested: {"City": "Tokyo"}}, "Name": {value: "John"}}.
+func scopeOverrides(override Override) scopedOverrides {
+	scoped := make(scopedOverrides)
+	for key, value := range override {
+		head, rest := splitPath(key)
+		scope := scoped[head]
+		if rest == "" {
+			scope.value = value
+			scope.hasValue = true
+		} else {
+			if scope.nested == nil {
+				scope.nested = make(Override)
+			}
+			scope.nested[rest] = value
+		}
+		scoped[head] = scope
+	}
+	return scoped
+}
+
+// element returns the overrides for the slice element or map entry name,
+// combining those addressed to every element with those addressed to name.
+// Overrides addressed to name take precedence.
+func (s scopedOverrides) element(name string) overrideScope {
+	all, one := s["*"], s[name]
+	scope := overrideScope{nested: mergeOverrides([]Override{all.nested, one.nested})}
+	if one.hasValue {
+		scope.value, scope.hasValue = one.value, true
+	} else if all.hasValue {
+		scope.value, scope.hasValue = all.value, true
+	}
+	return scope
+}
+
+// splitPath splits an override key into its first segment and the rest.
+// Field names are returned as is and indexes without brackets:
+//
+//	"Address.City"    -> "Address", "City"
+//	"Items[0].Name"   -> "Items", "[0].Name"
+//	"[0].Name"        -> "0", "Name"
+//	"[*]"             -> "*", ""
+func splitPath(key string) (head, rest string) {
+	if strings.HasPrefix(key, "[") {
+		end := strings.Index(key, "]")
+		if end < 0 {
+			return key, ""
+		}
+		head, rest = key[1:end], key[end+1:]
+	} else {
+		end := strings.IndexAny(key, ".[")
+		if end < 0 {
+			return key, ""
+		}
+		head, rest = key[:end], key[end:]
+	}
+	return head, strings.TrimPrefix(rest, ".")
+}
+
+// applyOverrides sets the overrides in ctx on the existing value v,
+// leaving everything they do not address unchanged. Nil pointers and maps
+// on the way are allocated.
+func (a *Autofill) applyOverrides(v reflect.Value, ctx *context) error {
+	if len(ctx.overrides) == 0 {
+		return nil
+	}
+
+	scoped := scopeOverrides(ctx.overrides)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return a.applyOverrides(v.Elem(), ctx)
+	case reflect.Struct:
+		names := make([]string, 0, len(scoped))
+		for name := range scoped {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			field := v.FieldByName(name)
+			if !field.IsValid() || !field.CanSet() {
+				continue
+			}
+			if err := a.applyScope(field, ctx.withFieldName(name), scoped[name]); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if err := checkIndexes(ctx, scoped, v.Len()); err != nil {
+			return err
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := a.applyScope(v.Index(i), ctx.withElement(i), scoped.element(strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for name := range scoped {
+			if name == "*" {
+				continue
+			}
+			keyVal, err := parseMapKey(name, v.Type().Key())
+			if err != nil {
+				return err
+			}
+			if !v.MapIndex(keyVal).IsValid() {
+				v.SetMapIndex(keyVal, reflect.Zero(v.Type().Elem()))
+			}
+		}
+		for i, keyVal := range sortedMapKeys(v) {
+			name := fmt.Sprint(keyVal.Interface())
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(keyVal))
+			if err := a.applyScope(elem, ctx.withKey(i, name), scoped.element(name)); err != nil {
+				return err
+			}
+			v.SetMapIndex(keyVal, elem)
+		}
+	}
+
+	return nil
+}
+
+// applyScope sets the override value of scope on dst, if any, and then
+// applies the nested overrides of scope.
+func (a *Autofill) applyScope(dst reflect.Value, ctx *context, scope overrideScope) error {
+	nested := scope.nested
+	if scope.hasValue {
+		resolved, err := resolveOverride(scope.value, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to resolve override for %s: %w", ctx.Path(), err)
+		}
+		if sub, ok := resolved.(Override); ok {
+			nested = mergeOverrides([]Override{sub, nested})
+		} else if resolved != nil {
+			if err := setFieldValue(dst, resolved); err != nil {
+				return fmt.Errorf("failed to set override for %s: %w", ctx.Path(), err)
+			}
+			ctx.recordOverride(scope.value)
+		}
+	}
+	return a.applyOverrides(dst, ctx.withOverrides(nested))
+}
+
+// parseMapKey converts the key of an override path such as "Meta[key]"
+// to the key type of the map.
+func parseMapKey(name string, keyType reflect.Type) (reflect.Value, error) {
+	var val interface{}
+	var err error
+
+	switch keyType.Kind() {
+	case reflect.String:
+		val = name
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val, err = strconv.ParseInt(name, 10, keyType.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val, err = strconv.ParseUint(name, 10, keyType.Bits())
+	case reflect.Bool:
+		val, err = strconv.ParseBool(name)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported map key type %s in override path", keyType)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid map key %q for key type %s: %w", name, keyType, err)
+	}
+	return reflect.ValueOf(val).Convert(keyType), nil
+}
+
+// sortedMapKeys returns the keys of m sorted by their string form,
+// so that maps are filled in a deterministic order.
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
@@ -0,0 +1,201 @@
+package autofill
+
+import (
+	"testing"
+)
+
+type pathAddress struct {
+	City    string
+	Country string
+	Zip     *string
+}
+
+type pathItem struct {
+	Name  string
+	Price int
+}
+
+type pathOrder struct {
+	ID       int64
+	Address  pathAddress
+	Billing  *pathAddress
+	Items    []pathItem
+	Meta     map[string]string
+	Counts   map[int]int
+	Customer string
+}
+
+func TestSplitPath(t *testing.T) {
+	tests := []struct {
+		key, head, rest string
+	}{
+		{"Name", "Name", ""},
+		{"Address.City", "Address", "City"},
+		{"Items[0].Name", "Items", "[0].Name"},
+		{"Items[*]", "Items", "[*]"},
+		{"[0].Name", "0", "Name"},
+		{"[*]", "*", ""},
+		{"[a.b]", "a.b", ""},
+		{"Meta[key]", "Meta", "[key]"},
+		{"Matrix[0][1]", "Matrix", "[0][1]"},
+		{"[0][1]", "0", "[1]"},
+	}
+
+	for _, tt := range tests {
+		head, rest := splitPath(tt.key)
+		if head != tt.head || rest != tt.rest {
+			t.Errorf("splitPath(%q) = %q, %q, expected %q, %q", tt.key, head, rest, tt.head, tt.rest)
+		}
+	}
+}
+
+func TestFill_NestedPathOverrides(t *testing.T) {
+	var order pathOrder
+	err := Fill(&order, Override{
+		"Address.City":   "Tokyo",
+		"Billing.City":   "Osaka",
+		"Items[0].Name":  "first",
+		"Items[*].Price": 500,
+		"Items[2].Price": 9,
+		"Meta[env]":      "staging",
+		"Counts[7]":      70,
+	})
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if order.Address.City != "Tokyo" {
+		t.Errorf("expected Address.City Tokyo, got %s", order.Address.City)
+	}
+	if order.Address.Country == "" {
+		t.Error("expected Address.Country to still be generated")
+	}
+	if order.Billing == nil || order.Billing.City != "Osaka" {
+		t.Errorf("expected Billing.City Osaka, got %+v", order.Billing)
+	}
+
+	if len(order.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(order.Items))
+	}
+	if order.Items[0].Name != "first" {
+		t.Errorf("expected Items[0].Name first, got %s", order.Items[0].Name)
+	}
+	if order.Items[1].Name == "" || order.Items[1].Name == "first" {
+		t.Errorf("expected Items[1].Name to be generated, got %q", order.Items[1].Name)
+	}
+	for i, want := range []int{500, 500, 9} {
+		if order.Items[i].Price != want {
+			t.Errorf("expected Items[%d].Price %d, got %d", i, want, order.Items[i].Price)
+		}
+	}
+
+	if order.Meta["env"] != "staging" {
+		t.Errorf("expected Meta[env] staging, got %v", order.Meta)
+	}
+	if len(order.Meta) != 4 {
+		t.Errorf("expected 3 generated entries plus env, got %v", order.Meta)
+	}
+	if order.Counts[7] != 70 {
+		t.Errorf("expected Counts[7] 70, got %v", order.Counts)
+	}
+}
+
+func TestFill_NestedOverrideMergesWithGeneration(t *testing.T) {
+	var order pathOrder
+	err := Fill(&order, Override{
+		"Address":      Override{"City": "Tokyo"},
+		"Address.Zip":  "100-0001",
+		"Items[1]":     Override{"Name": "second"},
+		"Billing":      pathAddress{City: "Kyoto"},
+		"Billing.Zip":  "600-0000",
+		"Items[2]":     pathItem{Name: "third", Price: 3},
+		"Items[2].Tag": "ignored",
+	})
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if order.Address.City != "Tokyo" || order.Address.Country == "" {
+		t.Errorf("expected Address to merge override with generation, got %+v", order.Address)
+	}
+	if order.Address.Zip == nil || *order.Address.Zip != "100-0001" {
+		t.Errorf("expected Address.Zip 100-0001, got %v", order.Address.Zip)
+	}
+	if order.Items[1].Name != "second" || order.Items[1].Price == 0 {
+		t.Errorf("expected Items[1] to merge override with generation, got %+v", order.Items[1])
+	}
+
+	// A struct value replaces generation, and nested keys apply on top of it
+	if order.Billing.City != "Kyoto" || order.Billing.Country != "" {
+		t.Errorf("expected Billing to be replaced, got %+v", order.Billing)
+	}
+	if order.Billing.Zip == nil || *order.Billing.Zip != "600-0000" {
+		t.Errorf("expected Billing.Zip 600-0000, got %v", order.Billing.Zip)
+	}
+	if order.Items[2] != (pathItem{Name: "third", Price: 3}) {
+		t.Errorf("expected Items[2] to be replaced, got %+v", order.Items[2])
+	}
+}
+
+func TestFillSlice_NestedSequenceOverrides(t *testing.T) {
+	orders := make([]pathOrder, 2)
+	err := FillSlice(&orders, Override{
+		"Address.City":   Seq("city%d"),
+		"Items[*].Price": SeqInt(100),
+	})
+	if err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	for i, order := range orders {
+		if want := Seq("city%d")(i); order.Address.City != want {
+			t.Errorf("order %d: expected Address.City %s, got %s", i, want, order.Address.City)
+		}
+		for j, item := range order.Items {
+			if item.Price != 100+j {
+				t.Errorf("order %d: expected Items[%d].Price %d, got %d", i, j, 100+j, item.Price)
+			}
+		}
+	}
+}
+
+func TestFill_NestedOverrideTypeMismatch(t *testing.T) {
+	var order pathOrder
+	err := Fill(&order, Override{"Items[0].Price": "expensive"})
+	if err == nil {
+		t.Fatal("expected error for type mismatch, got nil")
+	}
+}
+
+func TestFill_InvalidMapKeyOverride(t *testing.T) {
+	var order pathOrder
+	err := Fill(&order, Override{"Counts[seven]": 7})
+	if err == nil {
+		t.Fatal("expected error for invalid map key, got nil")
+	}
+}
+
+func TestFill_MapValuesPerEntry(t *testing.T) {
+	var order pathOrder
+	if err := New().WithMode(SequentialMode).Fill(&order); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if len(order.Meta) < 2 || len(order.Counts) < 2 {
+		t.Fatalf("expected generated maps, got %v and %v", order.Meta, order.Counts)
+	}
+
+	values := make(map[string]bool)
+	for _, v := range order.Meta {
+		values[v] = true
+	}
+	if len(values) != len(order.Meta) {
+		t.Errorf("expected a distinct value per entry, got %v", order.Meta)
+	}
+	counts := make(map[int]bool)
+	for _, v := range order.Counts {
+		counts[v] = true
+	}
+	if len(counts) != len(order.Counts) {
+		t.Errorf("expected a distinct value per entry, got %v", order.Counts)
+	}
+}
@@ -0,0 +1,172 @@
+package autofill
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// Persister stores filled values, e.g. by inserting them into a database.
+// Persist is called with a pointer to a struct and may write generated values
+// such as auto-increment IDs back into it.
+type Persister interface {
+	Persist(ctx gocontext.Context, v interface{}) error
+}
+
+// PersisterFunc adapts a function to the Persister interface.
+//
+// Example:
+//
+//	af := autofill.New().WithPersister(autofill.PersisterFunc(
+//	    func(ctx context.Context, v interface{}) error {
+//	        return repo.Save(ctx, v)
+//	    }))
+type PersisterFunc func(ctx gocontext.Context, v interface{}) error
+
+// Persist calls f(ctx, v).
+func (f PersisterFunc) Persist(ctx gocontext.Context, v interface{}) error {
+	return f(ctx, v)
+}
+
+// keyGenerator is implemented by Persisters that let the database generate
+// keys. Create applies the overrides returned by generatedKeys for the type of
+// the value below the passed overrides, so that the keys are left zero unless
+// they are overridden.
+type keyGenerator interface {
+	generatedKeys(typ reflect.Type) Override
+}
+
+// withGeneratedKeys returns overrides preceded by the overrides that leave the
+// keys generated by p for values of typ zero.
+func withGeneratedKeys(p Persister, typ reflect.Type, overrides []Override) []Override {
+	g, ok := p.(keyGenerator)
+	if !ok {
+		return overrides
+	}
+	keys := g.generatedKeys(typ)
+	if len(keys) == 0 {
+		return overrides
+	}
+	return append([]Override{keys}, overrides...)
+}
+
+// errNoPersister is returned by Create when no Persister is configured.
+var errNoPersister = errors.New("no persister configured, use WithPersister")
+
+// WithPersister sets the Persister used by Create.
+func (a *Autofill) WithPersister(p Persister) *Autofill {
+	a.persister = p
+	return a
+}
+
+// Create fills v like Fill and then persists it with the Persister set by
+// WithPersister. Like Factory.Create, each call for the same type fills with
+// the next index, starting at 0, so that sequences and unique values continue
+// across calls instead of repeating the first value.
+//
+// Example:
+//
+//	af := autofill.New().WithPersister(autofill.NewSQLPersister(db, autofill.Postgres))
+//	var user User
+//	err := af.Create(ctx, &user, autofill.Override{"Role": "admin"})
+//	// user.ID now holds the ID generated by the database
+func (a *Autofill) Create(ctx gocontext.Context, v interface{}, overrides ...Override) error {
+	if a.persister == nil {
+		return errNoPersister
+	}
+	typ := reflect.TypeOf(v)
+	if err := a.FillWithIndex(v, a.createIndex(typ), withGeneratedKeys(a.persister, typ, overrides)...); err != nil {
+		return err
+	}
+	if err := a.persister.Persist(ctx, v); err != nil {
+		return fmt.Errorf("failed to persist %T: %w", v, err)
+	}
+	return nil
+}
+
+// createIndex returns the next index used by Create for values of typ.
+func (a *Autofill) createIndex(typ reflect.Type) int {
+	counter, _ := a.created.LoadOrStore(typ, new(atomic.Int64))
+	return int(counter.(*atomic.Int64).Add(1) - 1)
+}
+
+// WithPersister sets the Persister used by Create and CreateN, replacing the
+// Persister copied from the Autofill instance.
+func (f *Factory[T]) WithPersister(p Persister) *Factory[T] {
+	f.filler.WithPersister(p)
+	return f
+}
+
+// Create builds a value like Build, calls the BeforeCreate hooks and persists it.
+//
+// Parents declared with BelongsTo are created before the value, and children
+// declared with HasMany after it, so that they can refer to keys generated
+// when persisting. Associated factories without a Persister use the
+// Persister of f.
+func (f *Factory[T]) Create(ctx gocontext.Context, overrides ...Override) (*T, error) {
+	v, err := f.createValue(ctx, nil, overrides)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface().(*T), nil
+}
+
+// CreateN creates n values with consecutive indexes of the sequence counter.
+func (f *Factory[T]) CreateN(ctx gocontext.Context, n int, overrides ...Override) ([]T, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("CreateN requires a non-negative count, got %d", n)
+	}
+
+	start := int(f.seq.Add(int64(n))) - n
+	items := make([]T, n)
+	for i := range items {
+		if err := f.createAt(ctx, &items[i], start+i, nil, overrides); err != nil {
+			return nil, fmt.Errorf("failed to create element at index %d: %w", i, err)
+		}
+	}
+	return items, nil
+}
+
+// createValue creates a new T with the next index of the sequence counter and
+// returns a pointer to it. fallback is used if f has no Persister.
+func (f *Factory[T]) createValue(ctx gocontext.Context, fallback Persister, overrides []Override) (reflect.Value, error) {
+	index := int(f.seq.Add(1) - 1)
+	v := new(T)
+	if err := f.createAt(ctx, v, index, fallback, overrides); err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(v), nil
+}
+
+// createAt builds v with index and its parents, calls the BeforeCreate hooks,
+// persists v and then creates its children.
+func (f *Factory[T]) createAt(ctx gocontext.Context, v *T, index int, fallback Persister, overrides []Override) error {
+	p := f.filler.persister
+	if p == nil {
+		p = fallback
+	}
+	if p == nil {
+		return errNoPersister
+	}
+
+	s := &session{ctx: ctx, persister: p}
+	if err := f.build(v, index, withGeneratedKeys(p, reflect.TypeOf(v), overrides), s); err != nil {
+		return err
+	}
+	for _, fn := range f.beforeCreate {
+		fn(v)
+	}
+	if err := p.Persist(ctx, v); err != nil {
+		return fmt.Errorf("failed to persist %T: %w", v, err)
+	}
+	return f.associateChildren(reflect.ValueOf(v).Elem(), index, overrides, s)
+}
+
+// session holds how associated values are created. A nil session means they
+// are only built.
+type session struct {
+	ctx       gocontext.Context
+	persister Persister
+}
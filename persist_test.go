@@ -0,0 +1,141 @@
+package autofill
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// memoryStore is a Persister that assigns consecutive IDs and records
+// the order in which values were persisted.
+type memoryStore struct {
+	nextID int64
+	order  []string
+}
+
+func (m *memoryStore) Persist(_ gocontext.Context, v interface{}) error {
+	m.nextID++
+	switch v := v.(type) {
+	case *assocUser:
+		v.ID = m.nextID
+		m.order = append(m.order, "user")
+	case *assocPost:
+		v.ID = m.nextID
+		m.order = append(m.order, "post")
+	case *assocOrder:
+		v.ID = m.nextID
+		m.order = append(m.order, "order")
+	case *assocOrderItem:
+		v.ID = m.nextID
+		m.order = append(m.order, "item")
+	default:
+		return errors.New("unexpected type")
+	}
+	return nil
+}
+
+func TestAutofill_Create(t *testing.T) {
+	var persisted *assocUser
+	af := New().WithPersister(PersisterFunc(func(_ gocontext.Context, v interface{}) error {
+		persisted = v.(*assocUser)
+		return nil
+	}))
+
+	var u assocUser
+	if err := af.Create(gocontext.Background(), &u, Override{"Name": "Ada"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if persisted != &u || u.Name != "Ada" {
+		t.Errorf("expected the filled user to be persisted, got %+v", persisted)
+	}
+
+	var next assocUser
+	if err := af.Create(gocontext.Background(), &next); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if next.ID != u.ID+1 {
+		t.Errorf("expected consecutive IDs across calls, got %d and %d", u.ID, next.ID)
+	}
+
+	if err := New().Create(gocontext.Background(), &u); err == nil {
+		t.Error("expected an error without a persister")
+	}
+
+	failing := New().WithPersister(PersisterFunc(func(gocontext.Context, interface{}) error {
+		return errors.New("boom")
+	}))
+	if err := failing.Create(gocontext.Background(), &u); err == nil {
+		t.Error("expected the persister error")
+	}
+}
+
+func TestFactory_Create(t *testing.T) {
+	store := &memoryStore{}
+	var hooks []string
+	users := NewFactory[assocUser](nil).
+		AfterBuild(func(*assocUser) { hooks = append(hooks, "after build") }).
+		BeforeCreate(func(*assocUser) { hooks = append(hooks, "before create") }).
+		WithPersister(store)
+
+	u, err := users.Create(gocontext.Background())
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if u.ID != 1 {
+		t.Errorf("expected ID 1 from the persister, got %d", u.ID)
+	}
+	if len(hooks) != 2 || hooks[0] != "after build" || hooks[1] != "before create" {
+		t.Errorf("unexpected hook order %v", hooks)
+	}
+
+	list, err := users.CreateN(gocontext.Background(), 2)
+	if err != nil {
+		t.Fatalf("CreateN failed: %v", err)
+	}
+	if list[0].ID != 2 || list[1].ID != 3 {
+		t.Errorf("expected IDs 2 and 3, got %d and %d", list[0].ID, list[1].ID)
+	}
+
+	if _, err := NewFactory[assocUser](nil).Create(gocontext.Background()); err == nil {
+		t.Error("expected an error without a persister")
+	}
+}
+
+func TestFactory_CreateAssociations(t *testing.T) {
+	store := &memoryStore{}
+	users := NewFactory[assocUser](nil)
+	posts := NewFactory[assocPost](nil).
+		WithPersister(store).
+		BelongsTo("AuthorID", users, "ID")
+
+	post, err := posts.Create(gocontext.Background())
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if post.AuthorID != 1 || post.ID != 2 {
+		t.Errorf("expected author 1 to be created before post 2, got %+v", post)
+	}
+
+	store = &memoryStore{}
+	items := NewFactory[assocOrderItem](nil)
+	orders := NewFactory[assocOrder](nil).
+		WithPersister(store).
+		HasMany("Items", items, 2, 2, "OrderID", "ID")
+
+	order, err := orders.Create(gocontext.Background())
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if order.ID != 1 || len(order.Items) != 2 {
+		t.Fatalf("unexpected order %+v", order)
+	}
+	for _, item := range order.Items {
+		if item.OrderID != order.ID || item.ID == 0 {
+			t.Errorf("expected item of order %d with a generated ID, got %+v", order.ID, item)
+		}
+	}
+	if got, want := fmt.Sprint(store.order), "[order item item]"; got != want {
+		t.Errorf("expected persist order %v, got %v", want, store.order)
+	}
+}
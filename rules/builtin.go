@@ -2,7 +2,6 @@ package rules
 
 import (
 	"fmt"
-	"math/rand"
 	"net/url"
 	"regexp"
 	"strings"
@@ -10,6 +9,31 @@ import (
 	"github.com/google/uuid"
 )
 
+// pick returns a value in [0, n).
+// If the context is in random mode the value is drawn from its random number
+// generator, otherwise it is derived from the index.
+func pick(ctx Context, n int) int {
+	if rc, ok := ctx.(randomContext); ok && rc.Randomized() {
+		return ctx.Rand().Intn(n)
+	}
+	return ctx.Index() % n
+}
+
+// number returns a non-negative number used to make generated values unique.
+// It is the index unless the context is in random mode.
+func number(ctx Context) int {
+	if rc, ok := ctx.(randomContext); ok && rc.Randomized() {
+		return ctx.Rand().Intn(100000)
+	}
+	return ctx.Index()
+}
+
+// edgeCase reports whether ctx requests a boundary value.
+func edgeCase(ctx Context) bool {
+	ec, ok := ctx.(edgeCaseContext)
+	return ok && ec.EdgeCase()
+}
+
 // EmailRule generates email addresses.
 type emailRule struct{}
 
@@ -22,10 +46,15 @@ func (r *emailRule) Generate(ctx Context) (interface{}, error) {
 	domains := []string{"example.com", "test.com", "mail.com", "email.com"}
 	prefixes := []string{"user", "test", "demo", "sample", "hello"}
 
-	prefix := prefixes[ctx.Index()%len(prefixes)]
-	domain := domains[ctx.Index()%len(domains)]
+	if edgeCase(ctx) {
+		// The longest local part allowed by RFC 5321
+		return strings.Repeat("a", 64) + "@" + domains[0], nil
+	}
+
+	prefix := prefixes[pick(ctx, len(prefixes))]
+	domain := domains[pick(ctx, len(domains))]
 
-	return fmt.Sprintf("%s%d@%s", prefix, ctx.Index(), domain), nil
+	return fmt.Sprintf("%s%d@%s", prefix, number(ctx), domain), nil
 }
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
@@ -60,8 +89,8 @@ func (r *urlRule) Generate(ctx Context) (interface{}, error) {
 	domains := []string{"example.com", "test.com", "demo.org", "sample.net"}
 	paths := []string{"/", "/home", "/about", "/contact", "/products", "/services"}
 
-	domain := domains[ctx.Index()%len(domains)]
-	path := paths[ctx.Index()%len(paths)]
+	domain := domains[pick(ctx, len(domains))]
+	path := paths[pick(ctx, len(paths))]
 
 	return fmt.Sprintf("%s://%s%s", r.scheme, domain, path), nil
 }
@@ -87,12 +116,9 @@ func UUID() Rule {
 }
 
 func (r *uuidRule) Generate(ctx Context) (interface{}, error) {
-	// Use deterministic UUID based on seed and index for reproducibility
-	seed := ctx.Seed() + int64(ctx.Index())
-	rng := rand.New(rand.NewSource(seed))
-
+	// Use deterministic UUID based on the context's seeded stream for reproducibility
 	var uuidBytes [16]byte
-	rng.Read(uuidBytes[:])
+	ctx.Rand().Read(uuidBytes[:])
 
 	// Set version (4) and variant bits
 	uuidBytes[6] = (uuidBytes[6] & 0x0f) | 0x40
@@ -131,9 +157,10 @@ func (r *rangeRule) Generate(ctx Context) (interface{}, error) {
 	if r.min == r.max {
 		return r.min, nil
 	}
-	// Use index-based deterministic value
-	rangeSize := r.max - r.min + 1
-	return r.min + (ctx.Index() % rangeSize), nil
+	if edgeCase(ctx) {
+		return []int{r.min, r.max}[ctx.Rand().Intn(2)], nil
+	}
+	return r.min + pick(ctx, r.max-r.min+1), nil
 }
 
 func (r *rangeRule) Validate(v interface{}) error {
@@ -169,8 +196,10 @@ func OneOf(options ...interface{}) Rule {
 }
 
 func (r *oneOfRule) Generate(ctx Context) (interface{}, error) {
-	idx := ctx.Index() % len(r.options)
-	return r.options[idx], nil
+	if edgeCase(ctx) {
+		return []interface{}{r.options[0], r.options[len(r.options)-1]}[ctx.Rand().Intn(2)], nil
+	}
+	return r.options[pick(ctx, len(r.options))], nil
 }
 
 func (r *oneOfRule) Validate(v interface{}) error {
@@ -221,14 +250,13 @@ func AlphaNumeric(length int) Rule {
 const alphaNumericChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
 func (r *alphaNumericRule) Generate(ctx Context) (interface{}, error) {
-	seed := ctx.Seed() + int64(ctx.Index())
-	rng := rand.New(rand.NewSource(seed))
+	gen := ctx.Rand()
 
 	var sb strings.Builder
 	sb.Grow(r.length)
 
 	for i := 0; i < r.length; i++ {
-		sb.WriteByte(alphaNumericChars[rng.Intn(len(alphaNumericChars))])
+		sb.WriteByte(alphaNumericChars[gen.Intn(len(alphaNumericChars))])
 	}
 
 	return sb.String(), nil
@@ -265,9 +293,7 @@ func Bool(trueRatio float64) Rule {
 }
 
 func (r *boolRule) Generate(ctx Context) (interface{}, error) {
-	seed := ctx.Seed() + int64(ctx.Index())
-	rng := rand.New(rand.NewSource(seed))
-	return rng.Float64() < r.trueRatio, nil
+	return ctx.Rand().Float64() < r.trueRatio, nil
 }
 
 func (r *boolRule) Validate(v interface{}) error {
@@ -1,6 +1,7 @@
 package rules
 
 import (
+	"math/rand"
 	"strings"
 	"testing"
 
@@ -18,8 +19,11 @@ func (m *mockContext) Locale() string                           { return m.local
 func (m *mockContext) Seed() int64                              { return m.seed }
 func (m *mockContext) Index() int                               { return m.index }
 func (m *mockContext) GetField(name string) (interface{}, bool) { return nil, false }
-func (m *mockContext) GetStruct() interface{}                   { return nil }
-func (m *mockContext) FieldName() string                        { return "" }
+func (m *mockContext) Rand() *rand.Rand {
+	return rand.New(rand.NewSource(m.seed + int64(m.index)))
+}
+func (m *mockContext) GetStruct() interface{} { return nil }
+func (m *mockContext) FieldName() string      { return "" }
 
 func newMockContext(index int) Context {
 	return &mockContext{
@@ -29,6 +33,22 @@ func newMockContext(index int) Context {
 	}
 }
 
+// mockRandomContext is a Context in random mode
+type mockRandomContext struct {
+	mockContext
+	rand *rand.Rand
+}
+
+func (m *mockRandomContext) Randomized() bool { return true }
+func (m *mockRandomContext) Rand() *rand.Rand { return m.rand }
+
+func newMockRandomContext(seed int64) Context {
+	return &mockRandomContext{
+		mockContext: mockContext{locale: "en_US", seed: seed},
+		rand:        rand.New(rand.NewSource(seed)),
+	}
+}
+
 func TestEmailRule(t *testing.T) {
 	rule := Email()
 	ctx := newMockContext(0)
@@ -228,6 +248,100 @@ func TestOneOfRule(t *testing.T) {
 	}
 }
 
+func TestRules_RandomContext(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+	}{
+		{"email", Email()},
+		{"url", URL()},
+		{"range", Range(1, 1000)},
+		{"oneof", OneOf("a", "b", "c", "d", "e", "f")},
+		{"uuid", UUID()},
+		{"alphanumeric", AlphaNumeric(8)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generate := func(seed int64) []interface{} {
+				ctx := newMockRandomContext(seed)
+				vals := make([]interface{}, 20)
+				for i := range vals {
+					val, err := tt.rule.Generate(ctx)
+					if err != nil {
+						t.Fatalf("Generate failed: %v", err)
+					}
+					if err := tt.rule.Validate(val); err != nil {
+						t.Errorf("Validate failed: %v", err)
+					}
+					vals[i] = val
+				}
+				return vals
+			}
+
+			first := generate(1)
+			second := generate(1)
+			other := generate(2)
+
+			for i := range first {
+				if first[i] != second[i] {
+					t.Errorf("expected same value for same seed at %d, got %v and %v", i, first[i], second[i])
+				}
+			}
+
+			same := true
+			for i := range first {
+				if first[i] != other[i] {
+					same = false
+				}
+			}
+			if same {
+				t.Error("expected different values for different seeds")
+			}
+		})
+	}
+}
+
+// mockEdgeContext is a Context that always requests boundary values
+type mockEdgeContext struct {
+	mockRandomContext
+}
+
+func (m *mockEdgeContext) EdgeCase() bool { return true }
+
+func TestRules_EdgeCases(t *testing.T) {
+	ctx := &mockEdgeContext{mockRandomContext: *newMockRandomContext(1).(*mockRandomContext)}
+
+	for i := 0; i < 20; i++ {
+		val, err := Range(10, 20).Generate(ctx)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if val != 10 && val != 20 {
+			t.Errorf("expected range bound, got %v", val)
+		}
+
+		val, err = OneOf("a", "b", "c").Generate(ctx)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if val != "a" && val != "c" {
+			t.Errorf("expected first or last option, got %v", val)
+		}
+	}
+
+	email, err := Email().Generate(ctx)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := Email().Validate(email); err != nil {
+		t.Errorf("expected valid edge case email, got %v: %v", email, err)
+	}
+	if !strings.HasPrefix(email.(string), strings.Repeat("a", 64)+"@") {
+		t.Errorf("expected 64 character local part, got %s", email)
+	}
+}
+
 func TestOneOfRule_Panic(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"math/rand"
 	"sync"
 )
 
@@ -11,11 +12,25 @@ type Context interface {
 	Locale() string
 	Seed() int64
 	Index() int
+	// Rand returns the random number generator for the current field,
+	// backed by the random source configured on the Autofill instance.
+	Rand() *rand.Rand
 	GetField(name string) (interface{}, bool)
 	GetStruct() interface{}
 	FieldName() string
 }
 
+// randomContext is implemented by contexts that can be switched to random mode.
+// The autofill package's Context implements it.
+type randomContext interface {
+	Randomized() bool
+}
+
+// edgeCaseContext is implemented by contexts that can request boundary values.
+type edgeCaseContext interface {
+	EdgeCase() bool
+}
+
 // Rule defines the interface for value generation rules.
 // Rules can generate values based on context and validate generated values.
 type Rule interface {
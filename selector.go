@@ -0,0 +1,86 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Set creates an Override for the field selected by sel.
+// Unlike string keys, the compiler checks the field name and the value type,
+// so renaming a field or changing its type breaks the build instead of
+// silently disabling the override. Nested struct fields can be selected too.
+//
+// Example:
+//
+//	autofill.Fill(&user,
+//	    autofill.Set(func(u *User) *string { return &u.Name }, "John"),
+//	    autofill.Set(func(u *User) *string { return &u.Address.City }, "Tokyo"),
+//	)
+//
+// sel must return the address of a field of its argument that is reachable
+// without following pointers, slices or maps; Set panics otherwise.
+func Set[T, F any](sel func(*T) *F, value F) Override {
+	return Override{fieldPath(sel): value}
+}
+
+// SetSeq creates an Override for the field selected by sel whose value is
+// generated from the index, like a SequenceFunc.
+//
+// Example:
+//
+//	autofill.FillSlice(&users,
+//	    autofill.SetSeq(func(u *User) *int64 { return &u.ID }, func(i int) int64 { return int64(1000 + i) }),
+//	)
+func SetSeq[T, F any](sel func(*T) *F, fn func(index int) F) Override {
+	return Override{fieldPath(sel): SequenceFunc(func(index int) interface{} {
+		return fn(index)
+	})}
+}
+
+// SetFunc creates an Override for the field selected by sel whose value is
+// computed from the Context, like an OverrideFunc.
+func SetFunc[T, F any](sel func(*T) *F, fn func(ctx Context) (F, error)) Override {
+	return Override{fieldPath(sel): OverrideFunc(func(ctx Context) (interface{}, error) {
+		return fn(ctx)
+	})}
+}
+
+// fieldPath returns the path of the field selected by sel, e.g. "Address.City".
+// It calls sel on a new T and looks for the field at the returned address.
+func fieldPath[T, F any](sel func(*T) *F) string {
+	root := new(T)
+	target := sel(root)
+	targetType := reflect.TypeOf((*F)(nil)).Elem()
+
+	if path, ok := findField(reflect.ValueOf(root).Elem(), reflect.ValueOf(target).Pointer(), targetType, ""); ok {
+		return path
+	}
+	panic(fmt.Sprintf("autofill: selector must return the address of a field of %T", root))
+}
+
+// findField searches the struct v for the field at address addr with type typ
+// and returns its path. Outer fields are preferred over the inner fields that
+// share their address.
+func findField(v reflect.Value, addr uintptr, typ reflect.Type, prefix string) (string, bool) {
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldVal := v.Field(i)
+		path := prefix + field.Name
+		if fieldVal.Addr().Pointer() == addr && field.Type == typ {
+			return path, true
+		}
+		if found, ok := findField(fieldVal, addr, typ, path+"."); ok {
+			return found, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,101 @@
+package autofill
+
+import (
+	"fmt"
+	"testing"
+)
+
+type selectorAddress struct {
+	City    string
+	Country string
+}
+
+type selectorUser struct {
+	ID      int64
+	Name    string
+	Age     int
+	Address selectorAddress
+	Tags    []string
+}
+
+func TestFieldPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{"top-level", fieldPath(func(u *selectorUser) *string { return &u.Name }), "Name"},
+		{"first field", fieldPath(func(u *selectorUser) *int64 { return &u.ID }), "ID"},
+		{"nested struct", fieldPath(func(u *selectorUser) *selectorAddress { return &u.Address }), "Address"},
+		{"nested field sharing address", fieldPath(func(u *selectorUser) *string { return &u.Address.City }), "Address.City"},
+		{"nested field", fieldPath(func(u *selectorUser) *string { return &u.Address.Country }), "Address.Country"},
+		{"slice field", fieldPath(func(u *selectorUser) *[]string { return &u.Tags }), "Tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.path != tt.expected {
+				t.Errorf("expected path %s, got %s", tt.expected, tt.path)
+			}
+		})
+	}
+}
+
+func TestFieldPath_Panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic for selector not returning a field")
+		}
+	}()
+
+	other := "not a field"
+	fieldPath(func(u *selectorUser) *string { return &other })
+}
+
+func TestSet(t *testing.T) {
+	var user selectorUser
+	err := Fill(&user,
+		Set(func(u *selectorUser) *string { return &u.Name }, "John"),
+		Set(func(u *selectorUser) *int { return &u.Age }, 30),
+		Set(func(u *selectorUser) *string { return &u.Address.City }, "Tokyo"),
+	)
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if user.Name != "John" {
+		t.Errorf("expected Name John, got %s", user.Name)
+	}
+	if user.Age != 30 {
+		t.Errorf("expected Age 30, got %d", user.Age)
+	}
+	if user.Address.City != "Tokyo" {
+		t.Errorf("expected Address.City Tokyo, got %s", user.Address.City)
+	}
+	if user.Address.Country == "" {
+		t.Error("expected Address.Country to be generated")
+	}
+}
+
+func TestSetSeqAndSetFunc(t *testing.T) {
+	users := make([]selectorUser, 3)
+	err := FillSlice(&users,
+		SetSeq(func(u *selectorUser) *int64 { return &u.ID }, func(i int) int64 { return int64(1000 + i) }),
+		SetFunc(func(u *selectorUser) *string { return &u.Name }, func(ctx Context) (string, error) {
+			id, _ := ctx.GetField("ID")
+			return fmt.Sprintf("user-%d", id), nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	for i, u := range users {
+		if u.ID != int64(1000+i) {
+			t.Errorf("user %d: expected ID %d, got %d", i, 1000+i, u.ID)
+		}
+		if want := fmt.Sprintf("user-%d", 1000+i); u.Name != want {
+			t.Errorf("user %d: expected Name %s, got %s", i, want, u.Name)
+		}
+	}
+}
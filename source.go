@@ -0,0 +1,83 @@
+package autofill
+
+import (
+	"encoding/binary"
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// Source is a source of uniformly distributed random uint64 values.
+// It has the same method set as math/rand/v2.Source, so *rand.PCG and
+// *rand.ChaCha8 from math/rand/v2 implement it.
+type Source interface {
+	Uint64() uint64
+}
+
+// SourceFunc creates the Source of one random stream from its 64-bit seed.
+// Every field gets its own stream, seeded from a hash of the Autofill seed,
+// the type being filled, the field path and the index.
+type SourceFunc func(seed uint64) Source
+
+// MathRand returns a SourceFunc creating math/rand sources. Every stream
+// seeds a new 607-word generator, so it is much slower than PCG; use it only
+// when a custom generator or rule relies on math/rand itself.
+func MathRand() SourceFunc {
+	return func(seed uint64) Source {
+		return rand.NewSource(int64(seed)).(rand.Source64)
+	}
+}
+
+// PCG returns a SourceFunc creating math/rand/v2 PCG sources. This is the
+// default, since a PCG stream is cheap to seed for every field.
+func PCG() SourceFunc {
+	return func(seed uint64) Source {
+		return randv2.NewPCG(seed, splitmix64(seed))
+	}
+}
+
+// ChaCha8 returns a SourceFunc creating math/rand/v2 ChaCha8 sources.
+func ChaCha8() SourceFunc {
+	return func(seed uint64) Source {
+		var key [32]byte
+		s := seed
+		for i := 0; i < len(key); i += 8 {
+			s = splitmix64(s)
+			binary.LittleEndian.PutUint64(key[i:], s)
+		}
+		return randv2.NewChaCha8(key)
+	}
+}
+
+// newRand returns a *rand.Rand drawing from src.
+func newRand(src Source) *rand.Rand {
+	if s, ok := src.(rand.Source64); ok {
+		return rand.New(s)
+	}
+	return rand.New(&sourceAdapter{src: src})
+}
+
+// sourceAdapter adapts a Source to math/rand's Source64.
+type sourceAdapter struct {
+	src Source
+}
+
+// Uint64 returns the next value of the source.
+func (s *sourceAdapter) Uint64() uint64 {
+	return s.src.Uint64()
+}
+
+// Int63 returns the next value of the source as a non-negative integer.
+func (s *sourceAdapter) Int63() int64 {
+	return int64(s.src.Uint64() >> 1)
+}
+
+// Seed is a no-op, since streams are seeded when they are created.
+func (s *sourceAdapter) Seed(int64) {}
+
+// splitmix64 scrambles x, for deriving independent seeds from one seed.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
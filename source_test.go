@@ -0,0 +1,109 @@
+package autofill
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/m1a9s9a4/autofill/rules"
+)
+
+// diceRule draws from the context's random number generator.
+type diceRule struct{}
+
+func (r *diceRule) Generate(ctx rules.Context) (interface{}, error) {
+	return 1 + ctx.Rand().Intn(6), nil
+}
+
+func (r *diceRule) Validate(v interface{}) error {
+	return nil
+}
+
+type sourceRecord struct {
+	ID    string `autofill:"uuid"`
+	Name  string
+	Score float64
+	Roll  int `autofill:"rule=dice"`
+}
+
+func fillWithSource(t *testing.T, source SourceFunc) []sourceRecord {
+	t.Helper()
+
+	ruleSet := rules.DefaultRuleSet().Add("dice", &diceRule{})
+	af := New().WithSeed(42).WithMode(RandomMode).WithRules(ruleSet)
+	if source != nil {
+		af.WithSource(source)
+	}
+
+	records := make([]sourceRecord, 20)
+	if err := af.FillSlice(&records); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+	return records
+}
+
+func TestWithSource_Deterministic(t *testing.T) {
+	sources := map[string]SourceFunc{
+		"MathRand": MathRand(),
+		"PCG":      PCG(),
+		"ChaCha8":  ChaCha8(),
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			first := fillWithSource(t, source)
+			second := fillWithSource(t, source)
+			if !reflect.DeepEqual(first, second) {
+				t.Error("expected same data for same seed and source")
+			}
+
+			for i, r := range first {
+				if r.Roll < 1 || r.Roll > 6 {
+					t.Errorf("record %d: Roll %d is out of range [1, 6]", i, r.Roll)
+				}
+			}
+		})
+	}
+}
+
+func TestWithSource_DefaultIsPCG(t *testing.T) {
+	if !reflect.DeepEqual(fillWithSource(t, nil), fillWithSource(t, PCG())) {
+		t.Error("expected default source to be PCG")
+	}
+}
+
+func TestWithSource_SourcesDiffer(t *testing.T) {
+	mathRand := fillWithSource(t, MathRand())
+	pcg := fillWithSource(t, PCG())
+	chacha := fillWithSource(t, ChaCha8())
+
+	if reflect.DeepEqual(mathRand, pcg) || reflect.DeepEqual(pcg, chacha) {
+		t.Error("expected different sources to produce different data")
+	}
+}
+
+// countingSource is a user-provided Source returning consecutive values.
+type countingSource struct {
+	next uint64
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.next++
+	return s.next << 40
+}
+
+func TestWithSource_Custom(t *testing.T) {
+	var seeds []uint64
+	custom := func(seed uint64) Source {
+		seeds = append(seeds, seed)
+		return &countingSource{}
+	}
+
+	records := fillWithSource(t, custom)
+	if len(seeds) == 0 {
+		t.Fatal("expected custom source to be used")
+	}
+	if records[0].Score != records[1].Score {
+		t.Errorf("expected every stream of the counting source to start alike, got %f and %f",
+			records[0].Score, records[1].Score)
+	}
+}
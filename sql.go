@@ -0,0 +1,314 @@
+package autofill
+
+import (
+	gocontext "context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Dialect is the SQL dialect of a database.
+type Dialect int
+
+const (
+	// Postgres is the PostgreSQL dialect.
+	Postgres Dialect = iota
+	// MySQL is the MySQL and MariaDB dialect.
+	MySQL
+	// SQLite is the SQLite dialect.
+	SQLite
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case Postgres:
+		return "postgres"
+	case MySQL:
+		return "mysql"
+	case SQLite:
+		return "sqlite"
+	}
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+// quoteIdent quotes a table or column name.
+func (d Dialect) quoteIdent(name string) string {
+	if d == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// placeholder returns the placeholder of the i-th argument, starting at 1.
+func (d Dialect) placeholder(i int) string {
+	if d == Postgres {
+		return fmt.Sprintf("$%d", i)
+	}
+	return "?"
+}
+
+// SQLDB is the subset of *sql.DB used by SQLPersister.
+// *sql.Tx and *sql.Conn implement it as well.
+type SQLDB interface {
+	ExecContext(ctx gocontext.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx gocontext.Context, query string, args ...interface{}) *sql.Row
+}
+
+// SQLPersister is a Persister that inserts structs with database/sql.
+//
+// The table name is the result of a TableName() string method if the struct
+// has one, otherwise its type name in snake_case followed by "s", e.g. "order_items".
+// A name qualified by a schema, e.g. "public.users", is quoted part by part.
+// Columns are taken from the exported fields of basic types, []byte, time.Time
+// and driver.Valuer, or pointers to them; fields of embedded structs are included.
+// The column name is the name in the `db` tag, then in the `json` tag, then the
+// field name in snake_case. Fields tagged `db:"-"` are skipped.
+//
+// The key column is the field tagged `db:",pk"`, or else the field named ID.
+// If it is zero, it is left out of the INSERT and the key generated by the
+// database is written back into the struct, using RETURNING for Postgres and
+// LastInsertId otherwise. Create leaves integer keys zero, so that the database
+// generates them, unless they are overridden.
+type SQLPersister struct {
+	db      SQLDB
+	dialect Dialect
+}
+
+// NewSQLPersister creates a SQLPersister that inserts into db using dialect.
+//
+// Example:
+//
+//	af := autofill.New().WithPersister(autofill.NewSQLPersister(db, autofill.Postgres))
+func NewSQLPersister(db SQLDB, dialect Dialect) *SQLPersister {
+	return &SQLPersister{db: db, dialect: dialect}
+}
+
+// Persist inserts the struct pointed to by v.
+func (p *SQLPersister) Persist(ctx gocontext.Context, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("SQLPersister requires a pointer to struct, got %T", v)
+	}
+	elem := rv.Elem()
+
+	var (
+		names, placeholders []string
+		args                []interface{}
+		generated           *sqlColumn
+	)
+	columns := sqlColumns(elem.Type())
+	for i, col := range columns {
+		field := elem.FieldByIndex(col.index)
+		if col.key && field.IsZero() {
+			generated = &columns[i]
+			continue
+		}
+		names = append(names, p.dialect.quoteIdent(col.name))
+		args = append(args, sqlArg(field))
+		placeholders = append(placeholders, p.dialect.placeholder(len(args)))
+	}
+
+	query := "INSERT INTO " + p.dialect.quoteTable(tableName(elem)) + " "
+	switch {
+	case len(names) > 0:
+		query += "(" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	case p.dialect == MySQL:
+		query += "() VALUES ()"
+	default:
+		query += "DEFAULT VALUES"
+	}
+
+	if generated == nil {
+		_, err := p.db.ExecContext(ctx, query, args...)
+		return err
+	}
+
+	key := elem.FieldByIndex(generated.index)
+	if p.dialect == Postgres {
+		query += " RETURNING " + p.dialect.quoteIdent(generated.name)
+		return p.db.QueryRowContext(ctx, query, args...).Scan(key.Addr().Interface())
+	}
+
+	res, err := p.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get generated %s: %w", generated.name, err)
+	}
+	return setFieldValue(key, id)
+}
+
+// generatedKeys returns an override that leaves the key of the struct typ, or
+// of the struct typ points to, zero if it is an integer.
+func (p *SQLPersister) generatedKeys(typ reflect.Type) Override {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	for _, col := range sqlColumns(typ) {
+		if !col.key {
+			continue
+		}
+		field := typ.FieldByIndex(col.index)
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return Override{indexPath(typ, col.index): reflect.Zero(field.Type).Interface()}
+		}
+		return nil
+	}
+	return nil
+}
+
+// indexPath returns the override path of the field at index within the struct typ,
+// e.g. "Model.ID" for a key in an embedded struct.
+func indexPath(typ reflect.Type, index []int) string {
+	names := make([]string, len(index))
+	for i, j := range index {
+		field := typ.Field(j)
+		names[i] = field.Name
+		typ = field.Type
+	}
+	return strings.Join(names, ".")
+}
+
+// sqlColumn is a struct field mapped to a table column.
+type sqlColumn struct {
+	name  string
+	index []int
+	key   bool
+}
+
+// sqlColumns returns the columns of the struct type typ.
+func sqlColumns(typ reflect.Type) []sqlColumn {
+	columns := collectColumns(typ, nil)
+
+	hasKey := false
+	for _, col := range columns {
+		hasKey = hasKey || col.key
+	}
+	if !hasKey {
+		for i, col := range columns {
+			if typ.FieldByIndex(col.index).Name == "ID" {
+				columns[i].key = true
+				break
+			}
+		}
+	}
+	return columns
+}
+
+// collectColumns returns the columns of the struct type typ, whose fields are
+// at index within the root struct.
+func collectColumns(typ reflect.Type, index []int) []sqlColumn {
+	var columns []sqlColumn
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && !isColumnType(field.Type) {
+			columns = append(columns, collectColumns(field.Type, fieldIndex)...)
+			continue
+		}
+		if !field.IsExported() || !isColumnType(field.Type) {
+			continue
+		}
+
+		name, opts := parseColumnTag(field)
+		if name == "-" {
+			continue
+		}
+		columns = append(columns, sqlColumn{
+			name:  name,
+			index: fieldIndex,
+			key:   strings.Contains(","+opts+",", ",pk,"),
+		})
+	}
+	return columns
+}
+
+// parseColumnTag returns the column name of field and the options of its db tag.
+func parseColumnTag(field reflect.StructField) (name, opts string) {
+	if tag, ok := field.Tag.Lookup("db"); ok {
+		name, opts, _ = strings.Cut(tag, ",")
+	} else if tag, ok := field.Tag.Lookup("json"); ok {
+		name, _, _ = strings.Cut(tag, ",")
+	}
+	if name == "" {
+		name = snakeCase(field.Name)
+	}
+	return name, opts
+}
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// isColumnType reports whether values of typ can be stored in a single column.
+func isColumnType(typ reflect.Type) bool {
+	if typ.Implements(valuerType) || reflect.PtrTo(typ).Implements(valuerType) {
+		return true
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == timeType || typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// sqlArg returns the query argument for the value of field.
+func sqlArg(field reflect.Value) interface{} {
+	if field.Kind() == reflect.Ptr && field.IsNil() {
+		return nil
+	}
+	return field.Interface()
+}
+
+// tableName returns the table name of the struct v.
+func tableName(v reflect.Value) string {
+	if t, ok := v.Interface().(interface{ TableName() string }); ok {
+		return t.TableName()
+	}
+	if v.CanAddr() {
+		if t, ok := v.Addr().Interface().(interface{ TableName() string }); ok {
+			return t.TableName()
+		}
+	}
+	return snakeCase(v.Type().Name()) + "s"
+}
+
+// snakeCase converts a Go identifier to snake_case, keeping acronyms together,
+// e.g. "UserID" to "user_id" and "HTTPServer" to "http_server".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
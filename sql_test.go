@@ -0,0 +1,253 @@
+package autofill
+
+import (
+	gocontext "context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDB is a database/sql connector that records queries and returns
+// consecutive IDs from RETURNING and LastInsertId.
+type fakeDB struct {
+	mu      sync.Mutex
+	queries []fakeQuery
+	nextID  int64
+}
+
+type fakeQuery struct {
+	query string
+	args  []driver.Value
+}
+
+func (db *fakeDB) Connect(gocontext.Context) (driver.Conn, error) { return fakeConn{db}, nil }
+func (db *fakeDB) Driver() driver.Driver                          { return nil }
+
+func (db *fakeDB) record(query string, args []driver.Value) int64 {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.queries = append(db.queries, fakeQuery{query, args})
+	db.nextID++
+	return db.nextID
+}
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return fakeResult(s.db.record(s.query, args)), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{id: s.db.record(s.query, args)}, nil
+}
+
+type fakeResult int64
+
+func (r fakeResult) LastInsertId() (int64, error) { return int64(r), nil }
+func (r fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fakeRows struct {
+	id   int64
+	done bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.id
+	return nil
+}
+
+func openFakeDB(t *testing.T) (*sql.DB, *fakeDB) {
+	t.Helper()
+	fake := &fakeDB{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+type sqlTimestamps struct {
+	CreatedAt time.Time
+}
+
+type sqlAccount struct {
+	ID       int
+	Name     string `db:"full_name"`
+	Email    string `json:"email_address"`
+	Nickname *string
+	Avatar   []byte
+	Tags     []string
+	Password string `db:"-"`
+	sqlTimestamps
+}
+
+type sqlTag struct {
+	Code  string `db:"code,pk"`
+	Label string
+}
+
+func (sqlTag) TableName() string { return "labels" }
+
+func TestSQLPersister_Postgres(t *testing.T) {
+	db, fake := openFakeDB(t)
+	af := New().WithPersister(NewSQLPersister(db, Postgres))
+
+	var account sqlAccount
+	if err := af.Create(gocontext.Background(), &account, Override{"Name": "Ada"}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if account.ID != 1 {
+		t.Errorf("expected the generated ID 1 to be written back, got %d", account.ID)
+	}
+
+	want := `INSERT INTO "sql_accounts" ("full_name", "email_address", "nickname", "avatar", "created_at") VALUES ($1, $2, $3, $4, $5) RETURNING "id"`
+	if len(fake.queries) != 1 || fake.queries[0].query != want {
+		t.Fatalf("expected query\n  %s\ngot\n  %+v", want, fake.queries)
+	}
+	if args := fake.queries[0].args; args[0] != "Ada" || args[1] != account.Email {
+		t.Errorf("unexpected arguments %v", args)
+	}
+}
+
+func TestSQLPersister_MySQL(t *testing.T) {
+	db, fake := openFakeDB(t)
+	p := NewSQLPersister(db, MySQL)
+
+	account := sqlAccount{Name: "Ada"}
+	if err := p.Persist(gocontext.Background(), &account); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	if account.ID != 1 {
+		t.Errorf("expected LastInsertId 1 to be written back, got %d", account.ID)
+	}
+
+	want := "INSERT INTO `sql_accounts` (`full_name`, `email_address`, `nickname`, `avatar`, `created_at`) VALUES (?, ?, ?, ?, ?)"
+	if len(fake.queries) != 1 || fake.queries[0].query != want {
+		t.Fatalf("expected query\n  %s\ngot\n  %+v", want, fake.queries)
+	}
+	if fake.queries[0].args[2] != nil {
+		t.Errorf("expected a NULL nickname, got %v", fake.queries[0].args[2])
+	}
+}
+
+func TestSQLPersister_ExplicitKey(t *testing.T) {
+	db, fake := openFakeDB(t)
+	p := NewSQLPersister(db, SQLite)
+
+	tag := sqlTag{Code: "go", Label: "Go"}
+	if err := p.Persist(gocontext.Background(), &tag); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	want := `INSERT INTO "labels" ("code", "label") VALUES (?, ?)`
+	if len(fake.queries) != 1 || fake.queries[0].query != want {
+		t.Fatalf("expected query\n  %s\ngot\n  %+v", want, fake.queries)
+	}
+	if !reflect.DeepEqual(fake.queries[0].args, []driver.Value{"go", "Go"}) {
+		t.Errorf("unexpected arguments %v", fake.queries[0].args)
+	}
+
+	if err := p.Persist(gocontext.Background(), tag); err == nil {
+		t.Error("expected an error for a non-pointer value")
+	}
+}
+
+type sqlSchemaUser struct {
+	ID   int
+	Name string
+}
+
+func (sqlSchemaUser) TableName() string { return "public.users" }
+
+func TestSQLPersister_SchemaTable(t *testing.T) {
+	db, fake := openFakeDB(t)
+	p := NewSQLPersister(db, Postgres)
+
+	if err := p.Persist(gocontext.Background(), &sqlSchemaUser{Name: "Ada"}); err != nil {
+		t.Fatalf("Persist failed: %v", err)
+	}
+	want := `INSERT INTO "public"."users" ("name") VALUES ($1) RETURNING "id"`
+	if len(fake.queries) != 1 || fake.queries[0].query != want {
+		t.Fatalf("expected query\n  %s\ngot\n  %+v", want, fake.queries)
+	}
+}
+
+func TestSQLPersister_CreateKeys(t *testing.T) {
+	type sqlModel struct {
+		ID int64
+	}
+	type sqlPost struct {
+		sqlModel
+		Title string
+	}
+
+	db, fake := openFakeDB(t)
+	af := New().WithPersister(NewSQLPersister(db, SQLite))
+
+	var post sqlPost
+	if err := af.Create(gocontext.Background(), &post); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var account sqlAccount
+	if err := af.Create(gocontext.Background(), &account, Override{"ID": 7}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var tag sqlTag
+	if err := af.Create(gocontext.Background(), &tag); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	want := []string{
+		`INSERT INTO "sql_posts" ("title") VALUES (?)`,
+		`INSERT INTO "sql_accounts" ("id", "full_name", "email_address", "nickname", "avatar", "created_at") VALUES (?, ?, ?, ?, ?, ?)`,
+		`INSERT INTO "labels" ("code", "label") VALUES (?, ?)`,
+	}
+	if len(fake.queries) != len(want) {
+		t.Fatalf("expected %d queries, got %+v", len(want), fake.queries)
+	}
+	for i, q := range fake.queries {
+		if q.query != want[i] {
+			t.Errorf("expected query\n  %s\ngot\n  %s", want[i], q.query)
+		}
+	}
+	if post.ID != 1 || account.ID != 7 || tag.Code == "" {
+		t.Errorf("expected a generated, an overridden and a filled key, got %d, %d and %q", post.ID, account.ID, tag.Code)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":         "id",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"OrderItem":  "order_item",
+		"Address2":   "address2",
+		"createdAt":  "created_at",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package autofill
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlBatchSize is the number of rows in each INSERT statement written by WriteSQL.
+const sqlBatchSize = 100
+
+// WriteSQL writes the structs in slice, a slice or a pointer to a slice of
+// structs or pointers to structs, as INSERT statements into table, with up
+// to 100 rows per statement. Values are quoted and escaped for dialect.
+//
+// Columns are mapped as by SQLPersister: from the `db` tag, then the `json`
+// tag, then the field name in snake_case. The key column is left out if it is
+// zero in every row, so that the database generates it.
+//
+// Example:
+//
+//	users := make([]User, 1000)
+//	autofill.FillSlice(&users)
+//	f, _ := os.Create("seed.sql")
+//	defer f.Close()
+//	err := autofill.WriteSQL(f, "users", users, autofill.Postgres)
+func WriteSQL(w io.Writer, table string, slice interface{}, dialect Dialect) error {
+	rows, columns, err := sqlRows("WriteSQL", slice)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = dialect.quoteIdent(col.name)
+	}
+	header := "INSERT INTO " + dialect.quoteTable(table) + " (" + strings.Join(names, ", ") + ") VALUES\n"
+
+	bw := bufio.NewWriter(w)
+	for i, row := range rows {
+		if i%sqlBatchSize == 0 {
+			bw.WriteString(header)
+		}
+		bw.WriteString("  (")
+		for j, col := range columns {
+			if j > 0 {
+				bw.WriteString(", ")
+			}
+			literal, err := dialect.literal(row.FieldByIndex(col.index))
+			if err != nil {
+				return fmt.Errorf("failed to write %s of row %d: %w", col.name, i, err)
+			}
+			bw.WriteString(literal)
+		}
+		if i%sqlBatchSize == sqlBatchSize-1 || i == len(rows)-1 {
+			bw.WriteString(");\n")
+		} else {
+			bw.WriteString("),\n")
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteCopy writes the structs in slice as a PostgreSQL COPY ... FROM stdin
+// statement followed by its rows in text format, as written by pg_dump and
+// read by psql. Columns are mapped as by WriteSQL.
+func WriteCopy(w io.Writer, table string, slice interface{}) error {
+	rows, columns, err := sqlRows("WriteCopy", slice)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = Postgres.quoteIdent(col.name)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("COPY " + Postgres.quoteTable(table) + " (" + strings.Join(names, ", ") + ") FROM stdin;\n")
+	for i, row := range rows {
+		for j, col := range columns {
+			if j > 0 {
+				bw.WriteByte('\t')
+			}
+			text, err := copyText(row.FieldByIndex(col.index))
+			if err != nil {
+				return fmt.Errorf("failed to write %s of row %d: %w", col.name, i, err)
+			}
+			bw.WriteString(text)
+		}
+		bw.WriteByte('\n')
+	}
+	bw.WriteString("\\.\n")
+	return bw.Flush()
+}
+
+// sqlRows returns the structs in slice and their columns. fn is the name of
+// the calling function, used in errors.
+func sqlRows(fn string, slice interface{}) ([]reflect.Value, []sqlColumn, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, nil, fmt.Errorf("%s requires a slice of structs, got %T", fn, slice)
+	}
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("%s requires a slice of structs, got %T", fn, slice)
+	}
+
+	rows := make([]reflect.Value, v.Len())
+	for i := range rows {
+		row := v.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				return nil, nil, fmt.Errorf("%s: element at index %d is nil", fn, i)
+			}
+			row = row.Elem()
+		}
+		rows[i] = row
+	}
+
+	var columns []sqlColumn
+	for _, col := range sqlColumns(elem) {
+		if col.key && allZero(rows, col.index) {
+			continue
+		}
+		columns = append(columns, col)
+	}
+	return rows, columns, nil
+}
+
+// allZero reports whether the field at index is zero in every row.
+func allZero(rows []reflect.Value, index []int) bool {
+	for _, row := range rows {
+		if !row.FieldByIndex(index).IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteTable quotes a table name, which may be qualified by a schema, e.g. "public.users".
+func (d Dialect) quoteTable(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = d.quoteIdent(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// literal returns v as an SQL literal of the dialect.
+func (d Dialect) literal(v reflect.Value) (string, error) {
+	value, err := sqlValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return "NULL", nil
+	case bool:
+		if d == Postgres {
+			return strings.ToUpper(strconv.FormatBool(value)), nil
+		}
+		if value {
+			return "1", nil
+		}
+		return "0", nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case uint64:
+		return strconv.FormatUint(value, 10), nil
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			if d != Postgres {
+				return "", fmt.Errorf("%v cannot be written for %s", value, d)
+			}
+			return "'" + formatSpecialFloat(value) + "'", nil
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64), nil
+	case string:
+		return d.quoteString(value), nil
+	case []byte:
+		if d == Postgres {
+			return `'\x` + hex.EncodeToString(value) + "'", nil
+		}
+		return "X'" + hex.EncodeToString(value) + "'", nil
+	case time.Time:
+		if d == MySQL {
+			return "'" + value.UTC().Format("2006-01-02 15:04:05.999999") + "'", nil
+		}
+		return "'" + value.Format("2006-01-02 15:04:05.999999-07:00") + "'", nil
+	}
+	return "", fmt.Errorf("unsupported value of type %T", value)
+}
+
+// quoteString quotes s as a string literal. MySQL also treats backslashes
+// as escape characters by default.
+func (d Dialect) quoteString(s string) string {
+	if d == MySQL {
+		s = strings.NewReplacer(`\`, `\\`, "'", "''", "\x00", `\0`).Replace(s)
+	} else {
+		s = strings.ReplaceAll(s, "'", "''")
+	}
+	return "'" + s + "'"
+}
+
+// copyText returns v in the text format of PostgreSQL COPY.
+func copyText(v reflect.Value) (string, error) {
+	value, err := sqlValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return `\N`, nil
+	case bool:
+		if value {
+			return "t", nil
+		}
+		return "f", nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case uint64:
+		return strconv.FormatUint(value, 10), nil
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return formatSpecialFloat(value), nil
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64), nil
+	case string:
+		return copyEscaper.Replace(value), nil
+	case []byte:
+		return `\\x` + hex.EncodeToString(value), nil
+	case time.Time:
+		return value.Format("2006-01-02 15:04:05.999999-07:00"), nil
+	}
+	return "", fmt.Errorf("unsupported value of type %T", value)
+}
+
+var copyEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// sqlValue converts the field value v to nil, bool, int64, uint64, float64,
+// string, []byte or time.Time, calling Value on driver.Valuer types.
+func sqlValue(v reflect.Value) (interface{}, error) {
+	if v.CanInterface() {
+		if valuer, ok := v.Interface().(driver.Valuer); ok {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return nil, nil
+			}
+			value, err := valuer.Value()
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				return nil, nil
+			}
+			return sqlValue(reflect.ValueOf(value))
+		}
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		return sqlValue(v.Elem())
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time), nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32:
+		// Round-trip through the shortest float32 representation, so that
+		// 0.1 is written as 0.1 rather than 0.10000000149011612.
+		return strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'g', -1, 32), 64)
+	case reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.IsNil() {
+				return nil, nil
+			}
+			return v.Bytes(), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported value of type %s", v.Type())
+}
+
+// formatSpecialFloat formats NaN and infinities as PostgreSQL reads them.
+func formatSpecialFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case f > 0:
+		return "Infinity"
+	}
+	return "-Infinity"
+}
@@ -0,0 +1,126 @@
+package autofill
+
+import (
+	"bytes"
+	"database/sql"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type seedRow struct {
+	ID      int `autofill:"-"`
+	Name    string
+	Active  bool
+	Score   float32
+	Data    []byte
+	Note    sql.NullString
+	Deleted *time.Time
+	Created time.Time `db:"created_at"`
+}
+
+func seedRows() []seedRow {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+	return []seedRow{
+		{Name: "O'Brien", Active: true, Score: 0.1, Data: []byte{0xde, 0xad}, Note: sql.NullString{String: `a\b`, Valid: true}, Created: created},
+		{Name: "tab\there", Created: created},
+	}
+}
+
+func TestWriteSQL(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{Postgres, `INSERT INTO "public"."seed" ("name", "active", "score", "data", "note", "deleted", "created_at") VALUES
+  ('O''Brien', TRUE, 0.1, '\xdead', 'a\b', NULL, '2024-03-01 12:30:00+09:00'),
+  ('tab	here', FALSE, 0, NULL, NULL, NULL, '2024-03-01 12:30:00+09:00');
+`},
+		{MySQL, "INSERT INTO `public`.`seed` (`name`, `active`, `score`, `data`, `note`, `deleted`, `created_at`) VALUES\n" +
+			`  ('O''Brien', 1, 0.1, X'dead', 'a\\b', NULL, '2024-03-01 03:30:00'),
+  ('tab	here', 0, 0, NULL, NULL, NULL, '2024-03-01 03:30:00');
+`},
+		{SQLite, `INSERT INTO "public"."seed" ("name", "active", "score", "data", "note", "deleted", "created_at") VALUES
+  ('O''Brien', 1, 0.1, X'dead', 'a\b', NULL, '2024-03-01 12:30:00+09:00'),
+  ('tab	here', 0, 0, NULL, NULL, NULL, '2024-03-01 12:30:00+09:00');
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteSQL(&buf, "public.seed", seedRows(), tt.dialect); err != nil {
+				t.Fatalf("WriteSQL failed: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("expected\n%s\ngot\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteSQL_Batches(t *testing.T) {
+	filled := make([]seedRow, 250)
+	if err := New().FillSlice(&filled); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+	filled[0].ID = 1
+	rows := make([]*seedRow, len(filled))
+	for i := range filled {
+		rows[i] = &filled[i]
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSQL(&buf, "seed", &rows, Postgres); err != nil {
+		t.Fatalf("WriteSQL failed: %v", err)
+	}
+	out := buf.String()
+	if n := strings.Count(out, "INSERT INTO"); n != 3 {
+		t.Errorf("expected 3 statements, got %d", n)
+	}
+	if n := strings.Count(out, ");\n"); n != 3 {
+		t.Errorf("expected 3 terminated statements, got %d", n)
+	}
+	if !strings.Contains(out, `("id", "name"`) {
+		t.Error("expected the key column when a key is set")
+	}
+}
+
+func TestWriteSQL_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSQL(&buf, "seed", seedRow{}, Postgres); err == nil {
+		t.Error("expected an error for a struct")
+	}
+	if err := WriteSQL(&buf, "seed", []int{1}, Postgres); err == nil {
+		t.Error("expected an error for a slice of ints")
+	}
+	if err := WriteSQL(&buf, "seed", []*seedRow{nil}, Postgres); err == nil {
+		t.Error("expected an error for a nil element")
+	}
+
+	type measurement struct{ Value float64 }
+	if err := WriteSQL(&buf, "m", []measurement{{math.NaN()}}, MySQL); err == nil {
+		t.Error("expected an error for NaN in MySQL")
+	}
+	buf.Reset()
+	if err := WriteSQL(&buf, "m", []measurement{{math.Inf(1)}}, Postgres); err != nil || !strings.Contains(buf.String(), "'Infinity'") {
+		t.Errorf("expected 'Infinity' for Postgres, got %q (%v)", buf.String(), err)
+	}
+}
+
+func TestWriteCopy(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCopy(&buf, "seed", seedRows()); err != nil {
+		t.Fatalf("WriteCopy failed: %v", err)
+	}
+
+	want := `COPY "seed" ("name", "active", "score", "data", "note", "deleted", "created_at") FROM stdin;
+O'Brien	t	0.1	\\xdead	a\\b	\N	2024-03-01 12:30:00+09:00
+tab\there	f	0	\N	\N	\N	2024-03-01 12:30:00+09:00
+\.
+`
+	if buf.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+}
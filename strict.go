@@ -0,0 +1,325 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithStrict enables strict override validation.
+// In strict mode Fill and FillSlice return an *OverrideError when a key of
+// the defaults or of the passed overrides does not address a settable field,
+// for example because of a typo, a removed field or an unexported field,
+// or addresses a slice element beyond the length of the slice, which is 3
+// unless the slice itself is overridden.
+// Without strict mode such keys are silently ignored, which is the default.
+//
+//	af := autofill.New().WithStrict(true)
+//	err := af.Fill(&user, autofill.Override{"Emial": "john@example.com"})
+//	// autofill: invalid override keys for User:
+//	//   "Emial" (override): no such field, did you mean "Email"?
+func (a *Autofill) WithStrict(strict bool) *Autofill {
+	a.strict = strict
+	return a
+}
+
+// OverrideError is returned in strict mode when override keys do not
+// address a settable field of the filled type.
+type OverrideError struct {
+	Type reflect.Type
+	Keys []InvalidKey
+}
+
+// InvalidKey describes an override key that does not address a settable field.
+type InvalidKey struct {
+	// Key is the full override key, e.g. "Address.Cty".
+	Key string
+	// Source is "default" for keys set with WithDefaults, "override"
+	// for keys passed to Fill or FillSlice, and `trait "name"` for keys
+	// of a trait.
+	Source string
+	// Reason explains why the key is invalid.
+	Reason string
+	// Suggestion is the closest valid key, if there is one.
+	Suggestion string
+}
+
+func (e *OverrideError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "autofill: invalid override keys for %s:", e.Type.Name())
+	for _, k := range e.Keys {
+		fmt.Fprintf(&b, "\n  %q (%s): %s", k.Key, k.Source, k.Reason)
+		if k.Suggestion != "" {
+			fmt.Fprintf(&b, ", did you mean %q?", k.Suggestion)
+		}
+	}
+	return b.String()
+}
+
+// validateOverrides checks the defaults, the passed overrides and the traits
+// they apply against typ.
+func (a *Autofill) validateOverrides(typ reflect.Type, overrides []Override) error {
+	invalid := a.validateLayer(typ, a.defaults, "default")
+	for _, override := range overrides {
+		invalid = append(invalid, a.validateLayer(typ, override, "override")...)
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	return &OverrideError{Type: typ, Keys: invalid}
+}
+
+// validateLayer checks the keys of override and of the traits it applies.
+// Traits must have been expanded successfully before.
+func (a *Autofill) validateLayer(typ reflect.Type, override Override, source string) []InvalidKey {
+	var invalid []InvalidKey
+	names, _ := traitNames(override)
+	for _, name := range names {
+		invalid = append(invalid, a.validateLayer(typ, a.traits[name], fmt.Sprintf("trait %q", name))...)
+	}
+	return append(invalid, validateKeys(typ, withoutTraits(override), "", source)...)
+}
+
+// validateKeys returns the keys of override, relative to a value of type typ,
+// that do not address a settable field. Keys are reported with prefix and
+// in sorted order.
+func validateKeys(typ reflect.Type, override Override, prefix, source string) []InvalidKey {
+	keys := make([]string, 0, len(override))
+	for key := range override {
+		// Type overrides apply wherever the type appears, even nowhere
+		if prefix == "" && strings.HasPrefix(key, typeKeyPrefix) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var invalid []InvalidKey
+	for _, key := range keys {
+		fullKey := joinPath(prefix, key)
+		target, reason, suggestion := resolvePath(typ, key)
+		if reason != "" {
+			if suggestion != "" {
+				suggestion = joinPath(prefix, suggestion)
+			}
+			invalid = append(invalid, InvalidKey{Key: fullKey, Source: source, Reason: reason, Suggestion: suggestion})
+			continue
+		}
+		// An Override value addresses fields of the target
+		if sub, ok := override[key].(Override); ok {
+			invalid = append(invalid, validateKeys(target, sub, fullKey, source)...)
+		}
+	}
+	return invalid
+}
+
+// resolvePath returns the type addressed by the override key path relative to typ.
+// If the path is invalid, it returns the reason and, for misspelled field
+// names, the closest valid path.
+func resolvePath(typ reflect.Type, path string) (target reflect.Type, reason, suggestion string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	head, rest := splitPath(path)
+	var next reflect.Type
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		field, ok := directField(typ, head)
+		if !ok {
+			if promoted, ok := typ.FieldByName(head); ok && promoted.IsExported() {
+				return nil, "field is promoted from an embedded struct", promotedPath(typ, promoted.Index)
+			}
+			if suggestion = suggestField(typ, head); suggestion != "" && rest != "" {
+				suggestion = joinPath(suggestion, rest)
+			}
+			return nil, "no such field", suggestion
+		}
+		if !field.IsExported() {
+			return nil, "field is unexported", ""
+		}
+		next = field.Type
+	case reflect.Slice, reflect.Array:
+		if head != "*" {
+			i, err := strconv.Atoi(head)
+			if err != nil || i < 0 {
+				return nil, fmt.Sprintf("invalid index %q for %s", head, typ), ""
+			}
+			if typ.Kind() == reflect.Array && i >= typ.Len() {
+				return nil, fmt.Sprintf("index out of range for length %d", typ.Len()), ""
+			}
+		}
+		next = typ.Elem()
+	case reflect.Map:
+		if head != "*" {
+			if _, err := parseMapKey(head, typ.Key()); err != nil {
+				return nil, err.Error(), ""
+			}
+		}
+		next = typ.Elem()
+	default:
+		return nil, fmt.Sprintf("cannot address %q in %s", head, typ), ""
+	}
+
+	if rest == "" {
+		return next, "", ""
+	}
+	target, reason, suggestion = resolvePath(next, rest)
+	if suggestion != "" {
+		suggestion = joinPath(pathSegment(typ, head), suggestion)
+	}
+	return target, reason, suggestion
+}
+
+// indexRangeError is returned while filling in strict mode when an override
+// addresses a slice element beyond the length of the slice. Slice lengths are
+// only known while filling, since the slice may itself be overridden.
+type indexRangeError struct {
+	path   string
+	length int
+}
+
+func (e *indexRangeError) Error() string {
+	return fmt.Sprintf("index out of range for %s of length %d", e.path, e.length)
+}
+
+// checkIndexes returns an *indexRangeError in strict mode if scoped holds
+// overrides for an element at or beyond length of the slice in ctx.
+func checkIndexes(ctx *context, scoped scopedOverrides, length int) error {
+	if !ctx.strict {
+		return nil
+	}
+	first := -1
+	for name := range scoped {
+		if i, err := strconv.Atoi(name); err == nil && i >= length && (first < 0 || i < first) {
+			first = i
+		}
+	}
+	if first < 0 {
+		return nil
+	}
+	return &indexRangeError{path: fmt.Sprintf("%s[%d]", ctx.Path(), first), length: length}
+}
+
+// indexRangeKey returns the invalid key for err: the key of the layer with the
+// highest precedence that addresses the element, or its path if the element is
+// addressed by a nested Override.
+func indexRangeKey(layers []overrideLayer, err *indexRangeError) InvalidKey {
+	reason := fmt.Sprintf("index out of range for length %d", err.length)
+	for i := len(layers) - 1; i >= 0; i-- {
+		keys := make([]string, 0, len(layers[i].override))
+		for key := range layers[i].override {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if key == err.path || strings.HasPrefix(key, err.path+".") || strings.HasPrefix(key, err.path+"[") {
+				return InvalidKey{Key: key, Source: layerSource(layers[i]), Reason: reason}
+			}
+		}
+	}
+	return InvalidKey{Key: err.path, Source: "override", Reason: reason}
+}
+
+// layerSource returns the source of layer as reported in an InvalidKey.
+func layerSource(layer overrideLayer) string {
+	switch layer.origin {
+	case OriginDefault:
+		return "default"
+	case OriginTrait:
+		return fmt.Sprintf("trait %q", layer.trait)
+	}
+	return "override"
+}
+
+// directField returns the field of the struct typ named name,
+// ignoring fields promoted from embedded structs, which are not filled
+// from top-level keys.
+func directField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.Name == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// promotedPath returns the path of the promoted field at index, e.g. "Base.Name",
+// or "" if the field cannot be reached through exported fields.
+func promotedPath(typ reflect.Type, index []int) string {
+	names := make([]string, len(index))
+	for i, idx := range index {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		field := typ.Field(idx)
+		if !field.IsExported() {
+			return ""
+		}
+		names[i] = field.Name
+		typ = field.Type
+	}
+	return strings.Join(names, ".")
+}
+
+// suggestField returns the exported field of the struct typ closest to name,
+// or "" if no field is close enough to be a likely typo.
+func suggestField(typ reflect.Type, name string) string {
+	best, bestDist := "", -1
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		dist := levenshtein(strings.ToLower(name), strings.ToLower(field.Name))
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = field.Name, dist
+		}
+	}
+	if bestDist < 0 || bestDist > 2 || bestDist >= len(name) {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// pathSegment returns head formatted as a path segment of a value of type typ:
+// a field name for structs and an index for slices, arrays and maps.
+func pathSegment(typ reflect.Type, head string) string {
+	if typ.Kind() == reflect.Struct {
+		return head
+	}
+	return "[" + head + "]"
+}
+
+// joinPath joins two override paths, e.g. "Items" and "[0].Name".
+func joinPath(prefix, path string) string {
+	if prefix == "" || strings.HasPrefix(path, "[") {
+		return prefix + path
+	}
+	return prefix + "." + path
+}
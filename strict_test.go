@@ -0,0 +1,210 @@
+package autofill
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type StrictBase struct {
+	CreatedBy string
+}
+
+type strictItem struct {
+	Name  string
+	Price int
+}
+
+type strictAddress struct {
+	City    string
+	Country string
+}
+
+type strictOrder struct {
+	StrictBase
+	ID      int64
+	Email   string
+	Address strictAddress
+	Items   []strictItem
+	Meta    map[string]string
+	Counts  map[int]int
+	secret  string
+}
+
+func TestWithStrict_ValidKeys(t *testing.T) {
+	af := New().WithStrict(true).WithDefaults(Override{"Email": "default@example.com"})
+
+	var order strictOrder
+	err := af.Fill(&order, Override{
+		"ID":                   int64(1),
+		"Address.City":         "Tokyo",
+		"Address":              Override{"Country": "Japan"},
+		"Items[0].Name":        "first",
+		"Items[*].Price":       SeqInt(100),
+		"Meta[env]":            "staging",
+		"Counts[1]":            5,
+		"StrictBase.CreatedBy": "admin",
+	})
+	if err != nil {
+		t.Fatalf("expected valid keys to pass, got %v", err)
+	}
+	if order.Address.City != "Tokyo" || order.Address.Country != "Japan" {
+		t.Errorf("unexpected address %+v", order.Address)
+	}
+}
+
+func TestWithStrict_InvalidKeys(t *testing.T) {
+	af := New().WithStrict(true).WithDefaults(Override{"Adress.City": "Tokyo"})
+
+	var order strictOrder
+	err := af.Fill(&order, Override{
+		"Emial":          "john@example.com",
+		"secret":         "x",
+		"CreatedBy":      "admin",
+		"Items[0].Nmae":  "first",
+		"Items[x].Name":  "first",
+		"Counts[abc]":    1,
+		"Email.Domain":   "example.com",
+		"Address":        Override{"Cty": "Osaka"},
+		"CompletelyGone": true,
+	})
+
+	var overrideErr *OverrideError
+	if !errors.As(err, &overrideErr) {
+		t.Fatalf("expected *OverrideError, got %v", err)
+	}
+
+	expected := map[string]InvalidKey{
+		"Adress.City":    {Source: "default", Reason: "no such field", Suggestion: "Address.City"},
+		"Emial":          {Source: "override", Reason: "no such field", Suggestion: "Email"},
+		"secret":         {Source: "override", Reason: "field is unexported"},
+		"CreatedBy":      {Source: "override", Reason: "field is promoted from an embedded struct", Suggestion: "StrictBase.CreatedBy"},
+		"Items[0].Nmae":  {Source: "override", Reason: "no such field", Suggestion: "Items[0].Name"},
+		"Address.Cty":    {Source: "override", Reason: "no such field", Suggestion: "Address.City"},
+		"CompletelyGone": {Source: "override", Reason: "no such field"},
+	}
+	found := make(map[string]InvalidKey)
+	for _, k := range overrideErr.Keys {
+		found[k.Key] = k
+	}
+
+	for key, want := range expected {
+		got, ok := found[key]
+		if !ok {
+			t.Errorf("expected %q to be reported", key)
+			continue
+		}
+		if got.Source != want.Source || got.Reason != want.Reason || got.Suggestion != want.Suggestion {
+			t.Errorf("%q: expected %+v, got %+v", key, want, got)
+		}
+	}
+	for _, key := range []string{"Items[x].Name", "Counts[abc]", "Email.Domain"} {
+		if _, ok := found[key]; !ok {
+			t.Errorf("expected %q to be reported", key)
+		}
+	}
+	if len(overrideErr.Keys) != len(expected)+3 {
+		t.Errorf("expected %d invalid keys, got %d: %v", len(expected)+3, len(overrideErr.Keys), err)
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, `"Emial" (override): no such field, did you mean "Email"?`) {
+		t.Errorf("unexpected error message:\n%s", msg)
+	}
+}
+
+func TestWithStrict_FillSlice(t *testing.T) {
+	orders := make([]strictOrder, 3)
+	err := New().WithStrict(true).FillSlice(&orders, Override{"Emial": Seq("user%d@example.com")})
+
+	var overrideErr *OverrideError
+	if !errors.As(err, &overrideErr) {
+		t.Fatalf("expected *OverrideError, got %v", err)
+	}
+}
+
+func TestWithStrict_IndexOutOfRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaults  Override
+		overrides Override
+		want      InvalidKey
+	}{
+		{
+			name:      "default length",
+			overrides: Override{"Items[5].Price": 1, "Items[1].Price": 2},
+			want:      InvalidKey{Key: "Items[5].Price", Source: "override", Reason: "index out of range for length 3"},
+		},
+		{
+			name:      "overridden slice",
+			defaults:  Override{"Items[3].Name": "x"},
+			overrides: Override{"Items": []strictItem{{}, {}}},
+			want:      InvalidKey{Key: "Items[3].Name", Source: "default", Reason: "index out of range for length 2"},
+		},
+		{
+			name:      "nested override",
+			overrides: Override{"Items": Override{"[4]": strictItem{}}},
+			want:      InvalidKey{Key: "Items[4]", Source: "override", Reason: "index out of range for length 3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var order strictOrder
+			err := New().WithStrict(true).WithDefaults(tt.defaults).Fill(&order, tt.overrides)
+
+			var overrideErr *OverrideError
+			if !errors.As(err, &overrideErr) {
+				t.Fatalf("expected *OverrideError, got %v", err)
+			}
+			if len(overrideErr.Keys) != 1 || overrideErr.Keys[0] != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, overrideErr.Keys)
+			}
+		})
+	}
+
+	var order strictOrder
+	err := New().WithStrict(true).Fill(&order, Override{"Items": make([]strictItem, 6), "Items[5].Price": 1})
+	if err != nil || order.Items[5].Price != 1 {
+		t.Errorf("expected an index within an overridden slice to be valid, got %v", err)
+	}
+	if err := New().Fill(&order, Override{"Items[5].Price": 1}); err != nil {
+		t.Errorf("expected out of range indexes to be ignored without strict mode, got %v", err)
+	}
+
+	type fixed struct{ Scores [2]int }
+	var f fixed
+	err = New().WithStrict(true).Fill(&f, Override{"Scores[2]": 1})
+	var overrideErr *OverrideError
+	if !errors.As(err, &overrideErr) || overrideErr.Keys[0].Reason != "index out of range for length 2" {
+		t.Errorf("expected an out of range error for an array index, got %v", err)
+	}
+}
+
+func TestWithStrict_Disabled(t *testing.T) {
+	var order strictOrder
+	if err := New().Fill(&order, Override{"Emial": "john@example.com"}); err != nil {
+		t.Errorf("expected unknown keys to be ignored without strict mode, got %v", err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"email", "email", 0},
+		{"emial", "email", 2},
+		{"name", "nmae", 2},
+		{"city", "cty", 1},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package autofill
+
+import (
+	"fmt"
+	"strings"
+)
+
+// traitsKey is the reserved override key holding the trait names set by Traits.
+const traitsKey = "$traits"
+
+// WithTrait registers a named set of overrides that can be applied with Traits.
+// A trait can include other traits by containing Traits(...) itself.
+// Registering a trait with an existing name replaces it.
+//
+// Example:
+//
+//	af := autofill.New().
+//	    WithTrait("admin", autofill.Override{"Role": "admin", "Permissions": "all"}).
+//	    WithTrait("suspended", autofill.Override{"Status": "suspended"})
+//	af.Fill(&user, autofill.Traits("admin", "suspended"))
+func (a *Autofill) WithTrait(name string, override Override) *Autofill {
+	traits := make(map[string]Override, len(a.traits)+1)
+	for k, v := range a.traits {
+		traits[k] = v
+	}
+	traits[name] = override
+	a.traits = traits
+	return a
+}
+
+// Traits creates an Override that applies the named traits registered with WithTrait.
+// Overrides are applied in this order: defaults, then traits in the given order,
+// then the other overrides passed to Fill. Filling fails if a trait is not registered.
+//
+// Example:
+//
+//	af.FillSlice(&users, autofill.Traits("admin"), autofill.Override{
+//	    "Email": autofill.Seq("admin%d@example.com"),
+//	})
+func Traits(names ...string) Override {
+	return Override{traitsKey: names}
+}
+
+// overrideLayer is a set of overrides together with where it comes from.
+type overrideLayer struct {
+	origin   Origin
+	trait    string
+	override Override
+}
+
+// expandTraits returns the layers of overrides, in increasing precedence,
+// for the traits named in overrides followed by overrides without their
+// traits key, which come from origin.
+func (a *Autofill) expandTraits(overrides []Override, origin Origin) ([]overrideLayer, error) {
+	var traits, rest []overrideLayer
+	for _, override := range overrides {
+		names, err := traitNames(override)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			layers, err := a.traitLayers(name, nil)
+			if err != nil {
+				return nil, err
+			}
+			traits = append(traits, layers...)
+		}
+		rest = append(rest, overrideLayer{origin: origin, override: withoutTraits(override)})
+	}
+	return append(traits, rest...), nil
+}
+
+// traitLayers returns the layers of the trait name, including the traits it includes.
+// stack holds the traits being expanded, to detect cycles.
+func (a *Autofill) traitLayers(name string, stack []string) ([]overrideLayer, error) {
+	for _, s := range stack {
+		if s == name {
+			return nil, fmt.Errorf("trait %q includes itself: %s", name, strings.Join(append(stack, name), " -> "))
+		}
+	}
+
+	override, ok := a.traits[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown trait %q", name)
+	}
+
+	names, err := traitNames(override)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trait %q: %w", name, err)
+	}
+	var layers []overrideLayer
+	for _, included := range names {
+		sub, err := a.traitLayers(included, append(stack, name))
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, sub...)
+	}
+	return append(layers, overrideLayer{origin: OriginTrait, trait: name, override: withoutTraits(override)}), nil
+}
+
+// traitNames returns the trait names set by Traits in override.
+func traitNames(override Override) ([]string, error) {
+	value, ok := override[traitsKey]
+	if !ok {
+		return nil, nil
+	}
+	names, ok := value.([]string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be set with Traits, got %T", traitsKey, value)
+	}
+	return names, nil
+}
+
+// withoutTraits returns override without its traits key.
+func withoutTraits(override Override) Override {
+	if _, ok := override[traitsKey]; !ok {
+		return override
+	}
+	rest := make(Override, len(override)-1)
+	for k, v := range override {
+		if k != traitsKey {
+			rest[k] = v
+		}
+	}
+	return rest
+}
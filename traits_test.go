@@ -0,0 +1,157 @@
+package autofill
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type traitUser struct {
+	ID          int64 `autofill:"seq"`
+	Name        string
+	Role        string
+	Permissions string
+	Status      string
+	TenantID    int64
+}
+
+func newTraitFiller() *Autofill {
+	return New().
+		WithDefaults(Override{"Role": "member", "Status": "active", "TenantID": int64(1)}).
+		WithTrait("admin", Override{"Role": "admin", "Permissions": "all"}).
+		WithTrait("suspended", Override{"Status": "suspended", "Permissions": "none"}).
+		WithTrait("tenant1000", Override{"TenantID": int64(1000)})
+}
+
+func TestTraits(t *testing.T) {
+	af := newTraitFiller()
+
+	var user traitUser
+	if err := af.Fill(&user, Traits("admin")); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if user.Role != "admin" || user.Permissions != "all" {
+		t.Errorf("expected admin trait, got %+v", user)
+	}
+	if user.Status != "active" || user.TenantID != 1 {
+		t.Errorf("expected defaults for fields not set by the trait, got %+v", user)
+	}
+}
+
+func TestTraits_Precedence(t *testing.T) {
+	af := newTraitFiller()
+
+	// Later traits win over earlier ones, and passed overrides win over traits,
+	// regardless of the position of Traits among the overrides
+	var user traitUser
+	err := af.Fill(&user, Override{"Status": "pending"}, Traits("admin", "suspended"))
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if user.Role != "admin" {
+		t.Errorf("expected Role from admin trait, got %s", user.Role)
+	}
+	if user.Permissions != "none" {
+		t.Errorf("expected Permissions from later suspended trait, got %s", user.Permissions)
+	}
+	if user.Status != "pending" {
+		t.Errorf("expected Status from passed override, got %s", user.Status)
+	}
+
+	var combined traitUser
+	if err := af.Fill(&combined, Override{traitsKey: []string{"suspended", "admin"}, "Name": "John"}); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if combined.Permissions != "all" || combined.Name != "John" {
+		t.Errorf("expected admin trait applied last and Name override, got %+v", combined)
+	}
+}
+
+func TestTraits_Nested(t *testing.T) {
+	af := newTraitFiller().
+		WithTrait("enterprise_admin", Override{traitsKey: []string{"admin", "tenant1000"}, "Permissions": "enterprise"})
+
+	users := make([]traitUser, 3)
+	if err := af.FillSlice(&users, Traits("enterprise_admin")); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+	for i, u := range users {
+		if u.Role != "admin" || u.TenantID != 1000 || u.Permissions != "enterprise" {
+			t.Errorf("user %d: unexpected %+v", i, u)
+		}
+		if u.ID != int64(i) {
+			t.Errorf("user %d: expected ID %d, got %d", i, i, u.ID)
+		}
+	}
+}
+
+func TestTraits_InDefaults(t *testing.T) {
+	af := New().
+		WithTrait("admin", Override{"Role": "admin", "Permissions": "all"}).
+		WithDefaults(Override{traitsKey: []string{"admin"}, "Permissions": "some"})
+
+	var user traitUser
+	if err := af.Fill(&user, Traits()); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if user.Role != "admin" || user.Permissions != "some" {
+		t.Errorf("expected default traits below default keys, got %+v", user)
+	}
+}
+
+func TestTraits_Unknown(t *testing.T) {
+	var user traitUser
+	err := newTraitFiller().Fill(&user, Traits("admin", "superadmin"))
+	if err == nil || !strings.Contains(err.Error(), `unknown trait "superadmin"`) {
+		t.Errorf("expected unknown trait error, got %v", err)
+	}
+
+	users := make([]traitUser, 2)
+	if err := New().FillSlice(&users, Traits("admin")); err == nil {
+		t.Error("expected unknown trait error from FillSlice")
+	}
+}
+
+func TestTraits_Cycle(t *testing.T) {
+	af := New().
+		WithTrait("a", Traits("b")).
+		WithTrait("b", Traits("a"))
+
+	var user traitUser
+	err := af.Fill(&user, Traits("a"))
+	if err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
+
+func TestWithTrait_DoesNotAffectCopies(t *testing.T) {
+	base := New().WithTrait("admin", Override{"Role": "admin"})
+	copied := *base
+	base.WithTrait("admin", Override{"Role": "superadmin"})
+
+	var user traitUser
+	if err := copied.Fill(&user, Traits("admin")); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if user.Role != "admin" {
+		t.Errorf("expected copied filler to keep its trait, got %s", user.Role)
+	}
+}
+
+func TestTraits_Strict(t *testing.T) {
+	af := newTraitFiller().WithStrict(true).WithTrait("typo", Override{"Stauts": "x"})
+
+	var user traitUser
+	if err := af.Fill(&user, Traits("admin")); err != nil {
+		t.Fatalf("expected valid traits to pass, got %v", err)
+	}
+
+	err := af.Fill(&user, Traits("typo"))
+	var overrideErr *OverrideError
+	if !errors.As(err, &overrideErr) {
+		t.Fatalf("expected *OverrideError, got %v", err)
+	}
+	if len(overrideErr.Keys) != 1 || overrideErr.Keys[0].Source != `trait "typo"` || overrideErr.Keys[0].Suggestion != "Status" {
+		t.Errorf("unexpected invalid keys %+v", overrideErr.Keys)
+	}
+}
@@ -45,8 +45,8 @@ func TestTypeMatch_CorrectTypes(t *testing.T) {
 	var user UserWithUUID
 	err := Fill(&user, Override{
 		"ID":    "custom-uuid-12345", // string -> string: OK
-		"Age":   30,                   // int -> int: OK
-		"Email": "test@example.com",   // string -> string: OK
+		"Age":   30,                  // int -> int: OK
+		"Email": "test@example.com",  // string -> string: OK
 	})
 
 	if err != nil {
@@ -0,0 +1,88 @@
+package autofill
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// typeKeyPrefix prefixes the override keys set by OfType.
+const typeKeyPrefix = "$type:"
+
+// OfType creates an Override that applies to every value of type T that is
+// generated, wherever it appears: in fields, nested structs, pointers,
+// slice elements and map values. Overrides for a field by name take precedence.
+// value can be a direct value, a SequenceFunc or an OverrideFunc.
+// An Override value for a struct type overrides some of its fields and
+// generates the others.
+//
+// Example:
+//
+//	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+//	autofill.Fill(&order,
+//	    autofill.OfType[time.Time](ref),                               // every time.Time
+//	    autofill.OfType[Money](autofill.Override{"Currency": "JPY"}), // every Money
+//	)
+func OfType[T any](value interface{}) Override {
+	return Override{typeKey(reflect.TypeOf((*T)(nil)).Elem()): value}
+}
+
+// typeKey returns the override key for values of type typ.
+func typeKey(typ reflect.Type) string {
+	return typeKeyPrefix + typeName(typ)
+}
+
+// splitTypeOverrides splits override into field overrides and type overrides.
+func splitTypeOverrides(override Override) (fields, types Override) {
+	for key := range override {
+		if strings.HasPrefix(key, typeKeyPrefix) {
+			types = make(Override)
+			break
+		}
+	}
+	if types == nil {
+		return override, nil
+	}
+
+	fields = make(Override, len(override))
+	for key, value := range override {
+		if strings.HasPrefix(key, typeKeyPrefix) {
+			types[key] = value
+		} else {
+			fields[key] = value
+		}
+	}
+	return fields, types
+}
+
+// generateTypeOverride returns the value of the type override for typ in ctx, if any.
+func (a *Autofill) generateTypeOverride(typ reflect.Type, ctx *context) (interface{}, bool, error) {
+	value, ok := ctx.types[typeKey(typ)]
+	if !ok {
+		return nil, false, nil
+	}
+
+	resolved, err := resolveOverride(value, ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve override for type %s: %w", typ, err)
+	}
+	if sub, ok := resolved.(Override); ok {
+		// Overrides for fields by name take precedence over those for the type
+		val, err := a.generateByKind(typ, ctx.withOverrides(mergeOverrides([]Override{sub, ctx.overrides})))
+		return val, true, err
+	}
+	if resolved == nil {
+		return nil, false, nil
+	}
+
+	val := reflect.New(typ).Elem()
+	if err := setFieldValue(val, resolved); err != nil {
+		return nil, false, fmt.Errorf("failed to set override for type %s: %w", typ, err)
+	}
+	ctx.record(OriginTypeOverride, typ.String(), nil)
+	// Overrides for fields of the value, e.g. "Total.Currency", still apply
+	if err := a.applyOverrides(val, ctx); err != nil {
+		return nil, false, err
+	}
+	return val.Interface(), true, nil
+}
@@ -0,0 +1,139 @@
+package autofill
+
+import (
+	"testing"
+	"time"
+)
+
+type typeMoney struct {
+	Amount   int64
+	Currency string
+}
+
+type typeLine struct {
+	Name  string
+	Price typeMoney
+}
+
+type typeOrder struct {
+	ID        int64
+	CreatedAt time.Time `autofill:"now"`
+	ShippedAt *time.Time
+	Total     typeMoney
+	Lines     []typeLine
+	Fees      map[string]typeMoney
+}
+
+func TestOfType(t *testing.T) {
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var order typeOrder
+	err := Fill(&order,
+		OfType[time.Time](ref),
+		OfType[typeMoney](Override{"Currency": "JPY"}),
+	)
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if !order.CreatedAt.Equal(ref) {
+		t.Errorf("expected CreatedAt %v over the now tag, got %v", ref, order.CreatedAt)
+	}
+	if order.ShippedAt == nil || !order.ShippedAt.Equal(ref) {
+		t.Errorf("expected ShippedAt %v, got %v", ref, order.ShippedAt)
+	}
+
+	moneys := []typeMoney{order.Total}
+	for _, line := range order.Lines {
+		moneys = append(moneys, line.Price)
+	}
+	for _, fee := range order.Fees {
+		moneys = append(moneys, fee)
+	}
+	if len(moneys) != 7 {
+		t.Fatalf("expected 7 Money values, got %d", len(moneys))
+	}
+	for i, m := range moneys {
+		if m.Currency != "JPY" {
+			t.Errorf("money %d: expected Currency JPY, got %s", i, m.Currency)
+		}
+		if m.Amount == 0 {
+			t.Errorf("money %d: expected Amount to be generated", i)
+		}
+	}
+}
+
+func TestOfType_FieldOverridesWin(t *testing.T) {
+	ref := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	shipped := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	var order typeOrder
+	err := Fill(&order, OfType[time.Time](ref), OfType[typeMoney](Override{"Currency": "JPY", "Amount": int64(1)}), Override{
+		"ShippedAt":      shipped,
+		"Total.Currency": "USD",
+		"Lines[0].Price": typeMoney{Amount: 5, Currency: "EUR"},
+	})
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if !order.ShippedAt.Equal(shipped) {
+		t.Errorf("expected field override for ShippedAt, got %v", order.ShippedAt)
+	}
+	if order.Total.Currency != "USD" || order.Total.Amount != 1 {
+		t.Errorf("expected USD from field override and Amount from type override, got %+v", order.Total)
+	}
+	if order.Lines[0].Price != (typeMoney{5, "EUR"}) {
+		t.Errorf("expected Lines[0].Price from field override, got %+v", order.Lines[0].Price)
+	}
+	if order.Lines[1].Price != (typeMoney{1, "JPY"}) {
+		t.Errorf("expected Lines[1].Price from type override, got %+v", order.Lines[1].Price)
+	}
+}
+
+func TestOfType_ValueWithNestedOverrides(t *testing.T) {
+	var order typeOrder
+	err := Fill(&order, OfType[typeMoney](typeMoney{Amount: 10, Currency: "JPY"}), Override{
+		"Total.Currency":          "USD",
+		"Lines[*].Price.Amount":   int64(20),
+		"Fees[shipping].Currency": "EUR",
+	})
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	if order.Total != (typeMoney{Amount: 10, Currency: "USD"}) {
+		t.Errorf("expected Total {10 USD}, got %+v", order.Total)
+	}
+	for i, line := range order.Lines {
+		if line.Price != (typeMoney{Amount: 20, Currency: "JPY"}) {
+			t.Errorf("line %d: expected Price {20 JPY}, got %+v", i, line.Price)
+		}
+	}
+	if fee := order.Fees["shipping"]; fee != (typeMoney{Amount: 10, Currency: "EUR"}) {
+		t.Errorf("expected shipping fee {10 EUR}, got %+v", fee)
+	}
+}
+
+func TestOfType_Sequence(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	orders := make([]typeOrder, 3)
+	if err := New().WithStrict(true).FillSlice(&orders, OfType[time.Time](SeqTime(start, time.Hour))); err != nil {
+		t.Fatalf("FillSlice failed: %v", err)
+	}
+
+	for i, o := range orders {
+		want := start.Add(time.Duration(i) * time.Hour)
+		if !o.CreatedAt.Equal(want) || !o.ShippedAt.Equal(want) {
+			t.Errorf("order %d: expected times %v, got %v and %v", i, want, o.CreatedAt, o.ShippedAt)
+		}
+	}
+}
+
+func TestOfType_Mismatch(t *testing.T) {
+	var order typeOrder
+	if err := Fill(&order, OfType[time.Time]("not a time")); err == nil {
+		t.Error("expected error for value of the wrong type")
+	}
+}